@@ -14,6 +14,7 @@ const (
 	MarketDepth        = "/api/market/depth"
 	TradingviewHistory = "/tradingview/history"
 	ServertimeV3       = "/api/v3/servertime"
+	MarketSymbolV3     = "/api/v3/market/symbols"
 )
 
 // Secure endpoints v1, v2
@@ -75,3 +76,80 @@ const (
 	MarketWstokenV3          = "/api/v3/market/wstoken"
 	UserLimitsV3             = "/api/v3/user/limits"
 )
+
+// Group is a rate limit group of endpoints.
+// Bitkub enforces the request-per-second limits per group of endpoints.
+type Group string
+
+const (
+	GroupMarketData Group = "market-data" // Non-secure endpoints: market data, status and server time
+	GroupDepth      Group = "depth"       // The depth endpoint, limited on its own by Bitkub
+	GroupTrading    Group = "trading"     // Order placement, cancellation and order queries
+	GroupWallet     Group = "wallet"      // Wallet, balances, crypto, fiat and user endpoints
+)
+
+// groups maps the secure endpoints, and the public endpoints with their own limit, to their rate limit group,
+// every endpoint not listed here belongs to GroupMarketData.
+var groups = map[string]Group{
+	MarketDepth: GroupDepth,
+
+	MarketPlaceBid:       GroupTrading,
+	MarketPlaceAsk:       GroupTrading,
+	MarketPlaceBidTest:   GroupTrading,
+	MarketPlaceAskTest:   GroupTrading,
+	MarketPlaceAskByFiat: GroupTrading,
+	MarketCancelOrder:    GroupTrading,
+	MarketMyOpenOrder:    GroupTrading,
+	MarketMyOrderHistory: GroupTrading,
+	MarketOrderInfo:      GroupTrading,
+	MarketPlaceBidV2:     GroupTrading,
+	MarketPlaceAskV2:     GroupTrading,
+	MarketCancelOrderV2:  GroupTrading,
+
+	MarketPlaceBidV3:       GroupTrading,
+	MarketPlaceAskV3:       GroupTrading,
+	MarketCancelOrderV3:    GroupTrading,
+	MarketMyOpenOrderV3:    GroupTrading,
+	MarketMyOrderHistoryV3: GroupTrading,
+	MarketOrderInfoV3:      GroupTrading,
+
+	MarketWallet:           GroupWallet,
+	MarketBalances:         GroupWallet,
+	MarketWstoken:          GroupWallet,
+	CryptoAddresses:        GroupWallet,
+	CryptoWithdraw:         GroupWallet,
+	CryptoInternalWithdraw: GroupWallet,
+	CryptoDepositHistory:   GroupWallet,
+	CryptoWithdrawHistory:  GroupWallet,
+	CryptoGenerateAddress:  GroupWallet,
+	FiatAccounts:           GroupWallet,
+	FiatWithdraw:           GroupWallet,
+	FiatDepositHistory:     GroupWallet,
+	FiatWithdrawHistory:    GroupWallet,
+	UserLimits:             GroupWallet,
+	UserTradingCredits:     GroupWallet,
+
+	MarketWalletV3:           GroupWallet,
+	MarketBalancesV3:         GroupWallet,
+	MarketWstokenV3:          GroupWallet,
+	UserTradingCreditsV3:     GroupWallet,
+	UserLimitsV3:             GroupWallet,
+	CryptoAddressesV3:        GroupWallet,
+	CryptoWithdrawV3:         GroupWallet,
+	CryptoInternalWithdrawV3: GroupWallet,
+	CryptoDepositHistoryV3:   GroupWallet,
+	CryptoWithdrawHistoryV3:  GroupWallet,
+	CryptoGenerateAddressV3:  GroupWallet,
+	FiatAccountsV3:           GroupWallet,
+	FiatWithdrawV3:           GroupWallet,
+	FiatDepositHistoryV3:     GroupWallet,
+	FiatWithdrawHistoryV3:    GroupWallet,
+}
+
+// GroupOf returns the rate limit group of an endpoint.
+func GroupOf(endpoint string) Group {
+	if group, ok := groups[endpoint]; ok {
+		return group
+	}
+	return GroupMarketData
+}
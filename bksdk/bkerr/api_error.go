@@ -0,0 +1,163 @@
+package bkerr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// APIError is the error returned when the Bitkub API answers with a non-zero error code.
+// Use errors.As to read the code, or errors.Is with one of the Err* sentinels below.
+type APIError struct {
+	Code       int    // Bitkub error code (see the constants in this package)
+	Message    string // Error description from ErrorText
+	HTTPStatus int    // HTTP status code of the response
+	Endpoint   string // API path of the endpoint (e.g. /api/v3/market/place-bid)
+	RawBody    string // Raw response body
+}
+
+// NewAPIError creates an APIError for the given error code.
+// The message is filled from ErrorText.
+func NewAPIError(code int, httpStatus int, endpoint string, rawBody string) *APIError {
+	return &APIError{
+		Code:       code,
+		Message:    ErrorText(code),
+		HTTPStatus: httpStatus,
+		Endpoint:   endpoint,
+		RawBody:    rawBody,
+	}
+}
+
+// Error returns the error description, the same text as ErrorText(e.Code).
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an APIError with the same error code,
+// so errors.Is(err, bkerr.ErrInsufficientBalance) matches regardless of the endpoint.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+// newSentinel creates a sentinel APIError for comparing with errors.Is.
+func newSentinel(code int) *APIError {
+	return &APIError{Code: code, Message: ErrorText(code)}
+}
+
+// Sentinel errors for every Bitkub error code, use them with errors.Is.
+var (
+	ErrInvalidJSONPayload                 = newSentinel(InvalidJSONPayload)
+	ErrMissingXBTKAPIKEY                  = newSentinel(MissingXBTKAPIKEY)
+	ErrInvalidAPIKey                      = newSentinel(InvalidAPIKey)
+	ErrAPIPendingForActivation            = newSentinel(APIPendingForActivation)
+	ErrIPNotAllowed                       = newSentinel(IPNotAllowed)
+	ErrMissingInvalidSignature            = newSentinel(MissingInvalidSignature)
+	ErrMissingTimestamp                   = newSentinel(MissingTimestamp)
+	ErrInvalidTimestamp                   = newSentinel(InvalidTimestamp)
+	ErrInvalidUser                        = newSentinel(InvalidUser)
+	ErrInvalidParameter                   = newSentinel(InvalidParameter)
+	ErrInvalidSymbol                      = newSentinel(InvalidSymbol)
+	ErrInvalidAmount                      = newSentinel(InvalidAmount)
+	ErrInvalidRate                        = newSentinel(InvalidRate)
+	ErrImproperRate                       = newSentinel(ImproperRate)
+	ErrAmountTooLow                       = newSentinel(AmountTooLow)
+	ErrFailedToGetBalance                 = newSentinel(FailedToGetBalance)
+	ErrWalletIsEmpty                      = newSentinel(WalletIsEmpty)
+	ErrInsufficientBalance                = newSentinel(InsufficientBalance)
+	ErrFailedToInsertOrderIntoDb          = newSentinel(FailedToInsertOrderIntoDb)
+	ErrFailedToDeductBalance              = newSentinel(FailedToDeductBalance)
+	ErrInvalidOrderForCancellation        = newSentinel(InvalidOrderForCancellation)
+	ErrInvalidSide                        = newSentinel(InvalidSide)
+	ErrFailedToUpdateOrderStatus          = newSentinel(FailedToUpdateOrderStatus)
+	ErrInvalidOrderForLookup              = newSentinel(InvalidOrderForLookup)
+	ErrKYCLevel1IsRequiredToProceed       = newSentinel(KYCLevel1IsRequiredToProceed)
+	ErrLimitExceeds                       = newSentinel(LimitExceeds)
+	ErrPendingWithdrawalExists            = newSentinel(PendingWithdrawalExists)
+	ErrInvalidCurrencyForWithdrawal       = newSentinel(InvalidCurrencyForWithdrawal)
+	ErrAddressIsNotInWhitelist            = newSentinel(AddressIsNotInWhitelist)
+	ErrFailedToDeductCrypto               = newSentinel(FailedToDeductCrypto)
+	ErrFailedToCreateWithdrawalRecord     = newSentinel(FailedToCreateWithdrawalRecord)
+	ErrNonceHasToBeNumeric                = newSentinel(NonceHasToBeNumeric)
+	ErrInvalidNonce                       = newSentinel(InvalidNonce)
+	ErrWithdrawalLimitExceeds             = newSentinel(WithdrawalLimitExceeds)
+	ErrInvalidBankAccount                 = newSentinel(InvalidBankAccount)
+	ErrBankLimitExceeds                   = newSentinel(BankLimitExceeds)
+	ErrPendingWithdrawalExists2           = newSentinel(PendingWithdrawalExists2)
+	ErrWithdrawalIsUnderMaintenance       = newSentinel(WithdrawalIsUnderMaintenance)
+	ErrInvalidPermission                  = newSentinel(InvalidPermission)
+	ErrInvalidInternalAddress             = newSentinel(InvalidInternalAddress)
+	ErrAddressHasBeenDeprecated           = newSentinel(AddressHasBeenDeprecated)
+	ErrCancelOnlyMode                     = newSentinel(CancelOnlyMode)
+	ErrUserHasBeenSuspendedFromPurchasing = newSentinel(UserHasBeenSuspendedFromPurchasing)
+	ErrUserHasBeenSuspendedFromSelling    = newSentinel(UserHasBeenSuspendedFromSelling)
+	ErrServerError                        = newSentinel(ServerError)
+)
+
+// Code returns the Bitkub error code carried by err, or NoError if err is not an APIError.
+func Code(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return NoError
+}
+
+// IsRetryable reports whether err is a temporary failure and the same request may succeed
+// when sent again: a transport failure, a 429 or 5xx status, or the Bitkub server error code.
+// A request cancelled by its context is not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	switch Code(err) {
+	case ServerError:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAuthError reports whether err is caused by the API key, the signature,
+// the timestamp or the permissions of the key.
+func IsAuthError(err error) bool {
+	switch Code(err) {
+	case MissingXBTKAPIKEY,
+		InvalidAPIKey,
+		APIPendingForActivation,
+		IPNotAllowed,
+		MissingInvalidSignature,
+		MissingTimestamp,
+		InvalidTimestamp,
+		InvalidUser,
+		InvalidPermission:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsMaintenance reports whether err is caused by a maintenance mode of Bitkub,
+// such as withdrawals under maintenance or the cancel only mode.
+func IsMaintenance(err error) bool {
+	switch Code(err) {
+	case WithdrawalIsUnderMaintenance, CancelOnlyMode:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,22 @@
+package bkerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoData is returned by GetHistory when the requested range has no candle.
+var ErrNoData = errors.New("bitkub: no data")
+
+// HistoryError is returned by GetHistory when the status of the response is neither ok nor no_data.
+type HistoryError struct {
+	Status  string // Status of the response (e.g. error)
+	Message string // Error message of the response, if any
+}
+
+func (e *HistoryError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("bitkub history: status %s", e.Status)
+	}
+	return fmt.Sprintf("bitkub history: status %s: %s", e.Status, e.Message)
+}
@@ -0,0 +1,53 @@
+package bkerr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrClockSync is returned (wrapping the cause) when a secure request can not be signed
+// because the clock could not be synchronised with the Bitkub server time.
+var ErrClockSync = errors.New("bitkub: server time sync failed")
+
+// TransportError is returned when a request could not be sent or its response could not be read
+// (e.g. DNS failure, connection reset, timeout).
+type TransportError struct {
+	Endpoint string // API path of the endpoint
+	Err      error  // Underlying error from the http client
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("bitkub %s: %s", e.Endpoint, e.Err.Error())
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPError is returned when the API answers with a non-2xx status
+// and the body does not carry a Bitkub error code (e.g. 429, 5xx or an HTML error page).
+type HTTPError struct {
+	StatusCode int    // HTTP status code of the response
+	Endpoint   string // API path of the endpoint
+	Body       string // Raw response body
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("bitkub %s: unexpected status %d %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// DecodeError is returned when a successful response body can not be decoded.
+type DecodeError struct {
+	Endpoint string // API path of the endpoint
+	Body     string // Raw response body
+	Err      error  // Underlying error from the JSON decoder
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("bitkub %s: cannot decode response: %s", e.Endpoint, e.Err.Error())
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,7 @@
+package bkerr
+
+import "errors"
+
+// ErrUnknownSymbol is returned when a symbol is not listed by GetSymbols.
+// It is wrapped with the unknown symbols, use errors.Is to match it.
+var ErrUnknownSymbol = errors.New("bitkub: unknown symbol")
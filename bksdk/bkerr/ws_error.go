@@ -0,0 +1,29 @@
+package bkerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWsClosed is returned by the methods of a websocket client that has been closed.
+var ErrWsClosed = errors.New("bitkub: websocket client closed")
+
+// ErrWsAuth is wrapped by the WsError of a private connection whose authentication was rejected.
+var ErrWsAuth = errors.New("bitkub: websocket authentication rejected")
+
+// WsError is sent on the error channel of a websocket client.
+// The client keeps running after a WsError: a dial or read failure is followed by a reconnection,
+// and a message that can not be decoded is skipped.
+type WsError struct {
+	Op      string // Operation that failed: dial, auth, read or decode
+	Message string // Raw message that could not be decoded, empty for other operations
+	Err     error  // Underlying error
+}
+
+func (e *WsError) Error() string {
+	return fmt.Sprintf("bitkub websocket %s: %s", e.Op, e.Err.Error())
+}
+
+func (e *WsError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,138 @@
+package bksdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+)
+
+// call describes a single request to the Bitkub API.
+type call struct {
+	method     string     // http.MethodGet or http.MethodPost
+	endpoint   string     // API path, one of the constants in the api package
+	query      url.Values // Query parameters
+	payload    string     // JSON payload of a POST request
+	secure     bool       // Sign the request with the API key and secret
+	legacy     bool       // Sign a secure POST with the v1 scheme, ts and sig in the JSON payload
+	idempotent bool       // The call only reads data, so it is retried by the retry policy (GET calls always are)
+
+	// beforeRetry is called before every retry of the call.
+	// Returning true stops retrying and treats the call as successful.
+	beforeRetry func(ctx context.Context) (bool, error)
+}
+
+// errorEnvelope is the part of a response body carrying the Bitkub error code.
+type errorEnvelope struct {
+	Error int `json:"error"`
+}
+
+// do is the single pipeline every endpoint goes through.
+// It sends the call, checks the response and decodes the body into out (if out is not nil).
+// Idempotent calls are retried according to the retry policy.
+// It returns the raw response body and one of the following errors:
+// - *bkerr.TransportError when the request could not be sent or the body could not be read
+// - *bkerr.APIError when the body carries a non-zero Bitkub error code
+// - *bkerr.HTTPError when the status is not 2xx and the body has no error code
+// - *bkerr.DecodeError when the body can not be decoded into out
+// - the context error when ctx is done while waiting for the rate limiter or the backoff
+// - bkerr.ErrClockSync (wrapping the cause) when a secure call can not get a synchronised timestamp
+func (bksdk *SDK) do(ctx context.Context, c call, out any) ([]byte, error) {
+	// Only idempotent calls are retried
+	policy := bksdk.retryPolicy
+	maxAttempts := 1
+	if c.idempotent || c.method == http.MethodGet {
+		maxAttempts = policy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		body, err := bksdk.doOnce(ctx, c, out)
+		if err == nil || attempt >= maxAttempts || !policy.retryable(err) {
+			return body, err
+		}
+
+		// Wait for the backoff, give up with the last error when the context is done
+		if ctx.Err() != nil || policy.wait(ctx, attempt) != nil {
+			return body, err
+		}
+
+		// Give the caller a chance to find out that the previous attempt went through
+		if c.beforeRetry != nil {
+			done, checkErr := c.beforeRetry(ctx)
+			if checkErr != nil {
+				return body, err
+			}
+			if done {
+				return body, nil
+			}
+		}
+	}
+}
+
+// doOnce sends the call a single time.
+func (bksdk *SDK) doOnce(ctx context.Context, c call, out any) ([]byte, error) {
+	// Wait for the rate limiter of the endpoint group
+	if err := bksdk.waitRateLimit(ctx, c.endpoint); err != nil {
+		return nil, err
+	}
+
+	// Prepare the request, a secure call fails here when the clock can not be synchronised
+	req, err := bksdk.prepare(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	resp, body, err := bksdk.send(req)
+	if err != nil {
+		return nil, &bkerr.TransportError{Endpoint: c.endpoint, Err: err}
+	}
+
+	return body, handleResponse(c.endpoint, resp.StatusCode, body, out)
+}
+
+// prepare creates the http request for the call, signed when the call is secure.
+func (bksdk *SDK) prepare(ctx context.Context, c call) (*http.Request, error) {
+	switch {
+	case c.secure && c.legacy:
+		return bksdk.authPostLegacy(ctx, c.endpoint, c.payload)
+	case c.secure && c.method == http.MethodGet:
+		return bksdk.authGet(ctx, c.endpoint, c.query)
+	case c.secure:
+		return bksdk.authPost(ctx, c.endpoint, c.payload)
+	default:
+		return bksdk.newRequest(ctx, c.method, c.endpoint, c.query, c.payload)
+	}
+}
+
+// handleResponse turns the status code and body of a response into a typed error,
+// and decodes the body into out when the response is successful.
+func handleResponse(endpoint string, statusCode int, body []byte, out any) error {
+	// Read the Bitkub error code, if the body is a JSON object carrying one
+	var envelope errorEnvelope
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		_ = json.Unmarshal(trimmed, &envelope)
+	}
+
+	// A Bitkub error code is more precise than the status code
+	if envelope.Error != bkerr.NoError {
+		return bkerr.NewAPIError(envelope.Error, statusCode, endpoint, string(body))
+	}
+
+	// Check the response status code
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return &bkerr.HTTPError{StatusCode: statusCode, Endpoint: endpoint, Body: string(body)}
+	}
+
+	// Decode the response body
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return &bkerr.DecodeError{Endpoint: endpoint, Body: string(body), Err: err}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+// Package candles builds OHLCV candles of a symbol from the Bitkub trade websocket stream.
+//
+// Every trade updates the candle of its interval for each resolution of the builder. A candle is closed
+// once the trades or the clock are past the end of its interval plus a grace period for late trades,
+// and an interval without trades gives a flat candle at the previous close. The builder can be seeded
+// from GetHistory, so the candle in progress at startup is not missing the trades before the subscription.
+package candles
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/request"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+)
+
+// DefaultLateness is how long after the end of its interval a candle still accepts late trades.
+const DefaultLateness = 5 * time.Second
+
+// advanceInterval is how often Run closes the candles whose interval ended without new trades.
+const advanceInterval = time.Second
+
+// Duration returns the interval of a resolution (1, 5, 15, 60, 240 or 1D).
+func Duration(resolution string) (time.Duration, error) {
+	return request.ResolutionDuration(resolution)
+}
+
+// Source is the part of the SDK used to seed the builder, bksdk.SDKEndpoints satisfies it.
+type Source interface {
+	GetHistoryCtx(ctx context.Context, symbol string, resolution string, from, to time.Time) ([]response.Candle, error)
+}
+
+// Candle is the OHLCV of an interval.
+type Candle struct {
+	Symbol     string          // Symbol of the trades in lower case (e.g. thb_btc)
+	Resolution string          // Resolution of the candle (e.g. 15)
+	Time       time.Time       // Start of the interval, intervals are aligned on UTC
+	Open       decimal.Decimal // Rate of the first trade
+	High       decimal.Decimal // Highest rate
+	Low        decimal.Decimal // Lowest rate
+	Close      decimal.Decimal // Rate of the last trade
+	Volume     decimal.Decimal // Sum of the amounts in the base currency (e.g. BTC), 0 for an interval without trades
+}
+
+// Option configures a builder created with New.
+type Option func(*Builder)
+
+// WithLateness sets how long after the end of its interval a candle still accepts late trades.
+// Trades arriving later are dropped and counted, see Late.
+func WithLateness(lateness time.Duration) Option {
+	return func(b *Builder) {
+		b.lateness = lateness
+	}
+}
+
+// WithCandleHandler sets a function called with every closed candle, in the order of the intervals
+// of each resolution. It is called from the goroutine adding the trades, so it must not block.
+func WithCandleHandler(handler func(Candle)) Option {
+	return func(b *Builder) {
+		b.onCandle = handler
+	}
+}
+
+// Builder builds the candles of a symbol for one or more resolutions. It is safe for concurrent use.
+type Builder struct {
+	symbol   string
+	lateness time.Duration
+	onCandle func(Candle)
+
+	mu     sync.Mutex
+	series []*series
+	late   int
+}
+
+// series holds the candles of a resolution.
+type series struct {
+	resolution string
+	duration   time.Duration
+	open       []*bar    // Candles not closed yet, sorted by interval
+	last       *Candle   // Last closed candle, nil before the first one
+	next       time.Time // Interval of the next closed candle, zero before the first one
+}
+
+// bar is a candle being built.
+type bar struct {
+	Candle
+	first time.Time // Time of the trade that opened the candle
+	close time.Time // Time of the trade that closed the candle
+}
+
+// New creates the builder of a symbol in any convention (e.g. btc_thb or THB_BTC) for the resolutions (e.g. "1", "60", "1D").
+func New(symbol string, resolutions []string, opts ...Option) (*Builder, error) {
+	if len(resolutions) == 0 {
+		return nil, errors.New("candles: no resolution")
+	}
+
+	b := &Builder{
+		symbol:   bksdk.NormalizeSymbol(streamSymbol(symbol)),
+		lateness: DefaultLateness,
+	}
+	for _, resolution := range resolutions {
+		duration, err := request.ResolutionDuration(resolution)
+		if err != nil {
+			return nil, err
+		}
+		resolution, _ = request.ValidateResolution(resolution)
+		b.series = append(b.series, &series{resolution: resolution, duration: duration})
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b, nil
+}
+
+// Seed loads the candle in progress and the last closed candle of every resolution from GetHistory.
+// Call it after subscribing to the trade stream and before adding trades, so no trade is missed:
+// a trade both in the history and in the stream is counted twice.
+func (b *Builder) Seed(ctx context.Context, source Source) error {
+	now := time.Now().UTC()
+	for _, s := range b.series {
+		from := now.Truncate(s.duration).Add(-s.duration)
+		history, err := source.GetHistoryCtx(ctx, b.symbol, s.resolution, from, now)
+		if errors.Is(err, bkerr.ErrNoData) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		b.mu.Lock()
+		b.seed(s, history, now)
+		b.mu.Unlock()
+	}
+	return nil
+}
+
+// seed sets the candles of a series from the history.
+func (b *Builder) seed(s *series, history []response.Candle, now time.Time) {
+	current := now.Truncate(s.duration)
+	for _, h := range history {
+		candle := Candle{
+			Symbol:     b.symbol,
+			Resolution: s.resolution,
+			Time:       h.Time.UTC(),
+			Open:       h.Open,
+			High:       h.High,
+			Low:        h.Low,
+			Close:      h.Close,
+			Volume:     h.Volume,
+		}
+		switch {
+		case candle.Time.Equal(current):
+			// The candle in progress keeps receiving the trades of the stream
+			s.open = []*bar{{Candle: candle, first: candle.Time, close: candle.Time}}
+			s.next = current
+		case candle.Time.Before(current) && (s.last == nil || candle.Time.After(s.last.Time)):
+			closed := candle
+			s.last = &closed
+			if s.next.Before(current) {
+				s.next = candle.Time.Add(s.duration)
+			}
+		}
+	}
+}
+
+// Add adds a trade of the stream to the candles of every resolution, and closes the candles
+// whose interval ended before the trade. Trades of other symbols are ignored.
+func (b *Builder) Add(trade response.WsTrade) {
+	symbol := trade.Symbol
+	if symbol == "" {
+		_, symbol = response.ParseStream(trade.Stream)
+	}
+	if symbol != b.symbol {
+		return
+	}
+
+	at := response.Timestamp(trade.Ts).Time().UTC()
+
+	b.mu.Lock()
+	for _, s := range b.series {
+		if !b.add(s, at, trade.Rat, trade.Amt) {
+			b.late++
+		}
+	}
+	closed := b.flush(at)
+	b.mu.Unlock()
+
+	b.notify(closed)
+}
+
+// Advance closes the candles whose interval ended before now, including the flat candles of the intervals
+// without trades. Call it periodically when the trades are added with Add, Run does it every second.
+func (b *Builder) Advance(now time.Time) {
+	b.mu.Lock()
+	closed := b.flush(now.UTC())
+	b.mu.Unlock()
+
+	b.notify(closed)
+}
+
+// Run adds the trades of the channel (e.g. WsClient.Trades) and closes the candles on time
+// until ctx is done or the channel is closed. It returns the context error.
+func (b *Builder) Run(ctx context.Context, trades <-chan response.WsTrade) error {
+	ticker := time.NewTicker(advanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case trade, ok := <-trades:
+			if !ok {
+				return ctx.Err()
+			}
+			b.Add(trade)
+		case now := <-ticker.C:
+			b.Advance(now)
+		}
+	}
+}
+
+// Current returns the candle in progress of a resolution, false when it has no trade yet.
+func (b *Builder) Current(resolution string) (Candle, bool) {
+	resolution, err := request.ValidateResolution(resolution)
+	if err != nil {
+		return Candle{}, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, s := range b.series {
+		if s.resolution == resolution && len(s.open) > 0 {
+			return s.open[len(s.open)-1].Candle, true
+		}
+	}
+	return Candle{}, false
+}
+
+// Late returns the number of trades dropped because their candle was already closed, counted once per resolution.
+func (b *Builder) Late() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.late
+}
+
+// add adds a trade to the candle of its interval, it reports false when the candle is already closed.
+func (b *Builder) add(s *series, at time.Time, rate, amount decimal.Decimal) bool {
+	start := at.Truncate(s.duration)
+	if !s.next.IsZero() && start.Before(s.next) {
+		return false
+	}
+
+	// Find the candle of the interval, or insert a new one in order
+	i := sort.Search(len(s.open), func(i int) bool {
+		return !s.open[i].Time.Before(start)
+	})
+	if i == len(s.open) || !s.open[i].Time.Equal(start) {
+		candle := &bar{
+			Candle: Candle{Symbol: b.symbol, Resolution: s.resolution, Time: start, Open: rate, High: rate, Low: rate, Close: rate},
+			first:  at,
+			close:  at,
+		}
+		s.open = append(s.open, nil)
+		copy(s.open[i+1:], s.open[i:])
+		s.open[i] = candle
+	}
+
+	// A late trade may be older than the open or newer than the close of the candle
+	candle := s.open[i]
+	if at.Before(candle.first) {
+		candle.Open, candle.first = rate, at
+	}
+	if !at.Before(candle.close) {
+		candle.Close, candle.close = rate, at
+	}
+	if rate.GreaterThan(candle.High) {
+		candle.High = rate
+	}
+	if rate.LessThan(candle.Low) {
+		candle.Low = rate
+	}
+	candle.Volume = candle.Volume.Add(amount)
+	return true
+}
+
+// flush closes the candles of every resolution whose interval and grace period ended before now.
+func (b *Builder) flush(now time.Time) []Candle {
+	var closed []Candle
+	for _, s := range b.series {
+	series:
+		for {
+			// The next interval to close is the one after the last closed candle, or the first candle
+			start := s.next
+			if start.IsZero() {
+				if len(s.open) == 0 {
+					break
+				}
+				start = s.open[0].Time
+			}
+			if start.Add(s.duration + b.lateness).After(now) {
+				break
+			}
+
+			var candle Candle
+			switch {
+			case len(s.open) > 0 && s.open[0].Time.Equal(start):
+				candle = s.open[0].Candle
+				s.open = s.open[1:]
+			case s.last != nil:
+				// An interval without trades is flat at the previous close
+				candle = Candle{Symbol: b.symbol, Resolution: s.resolution, Time: start,
+					Open: s.last.Close, High: s.last.Close, Low: s.last.Close, Close: s.last.Close}
+			case len(s.open) > 0:
+				// Without a previous close, the first candle starts the series
+				s.next = s.open[0].Time
+				continue
+			default:
+				break series
+			}
+
+			closed = append(closed, candle)
+			s.last = &candle
+			s.next = start.Add(s.duration)
+		}
+	}
+	return closed
+}
+
+// notify calls the candle handler with the closed candles.
+func (b *Builder) notify(closed []Candle) {
+	if b.onCandle == nil {
+		return
+	}
+	for _, candle := range closed {
+		b.onCandle(candle)
+	}
+}
+
+// streamSymbol returns a symbol in any convention in the format of the trade stream (btc_thb gives thb_btc).
+func streamSymbol(symbol string) string {
+	sym, err := bksdk.ParseSymbol(symbol)
+	if err != nil {
+		return symbol
+	}
+	return sym.Format(bksdk.FormatStream)
+}
@@ -0,0 +1,123 @@
+package bksdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+)
+
+// DefaultClockSyncInterval is how often the offset with the server time is measured again.
+const DefaultClockSyncInterval = 5 * time.Minute
+
+// clock signs secure requests with the local time corrected by the offset measured against
+// /api/v3/servertime, instead of requesting the server time before every signed request.
+// The mutex only guards the fields, the server time is fetched without holding it.
+type clock struct {
+	mu       sync.Mutex
+	interval time.Duration                             // How often the offset is measured again
+	offset   time.Duration                             // Server time minus local time
+	synced   time.Time                                 // Local time of the last successful sync
+	pending  chan struct{}                             // Closed when the sync in flight ends, nil without one
+	now      func() time.Time                          // Local clock
+	fetch    func(ctx context.Context) (string, error) // Server time in milliseconds
+}
+
+func newClock(interval time.Duration, fetch func(ctx context.Context) (string, error)) *clock {
+	return &clock{interval: interval, now: time.Now, fetch: fetch}
+}
+
+// timestamp returns the current server time in milliseconds, as expected by X-BTK-TIMESTAMP.
+// The offset is measured again when it is older than the sync interval.
+// If that fails, the last offset keeps being used for one more interval,
+// after that (or when the clock never synced) the error is returned wrapped in bkerr.ErrClockSync.
+// A single call measures the offset at a time: the other calls use the last offset while it is usable,
+// or wait for the sync in flight until their own ctx is done.
+func (c *clock) timestamp(ctx context.Context) (string, error) {
+	for {
+		c.mu.Lock()
+		if !c.due(c.interval) {
+			defer c.mu.Unlock()
+			return c.format(), nil
+		}
+
+		// Another call is measuring the offset
+		if pending := c.pending; pending != nil {
+			if !c.due(2 * c.interval) {
+				defer c.mu.Unlock()
+				return c.format(), nil
+			}
+			c.mu.Unlock()
+
+			select {
+			case <-pending:
+				continue
+			case <-ctx.Done():
+				return "", fmt.Errorf("%w: %w", bkerr.ErrClockSync, ctx.Err())
+			}
+		}
+
+		// Measure the offset without holding the lock
+		pending := make(chan struct{})
+		c.pending = pending
+		c.mu.Unlock()
+
+		offset, synced, err := c.sync(ctx)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.pending = nil
+		close(pending)
+		if err == nil {
+			c.offset, c.synced = offset, synced
+		} else if c.due(2 * c.interval) {
+			return "", fmt.Errorf("%w: %w", bkerr.ErrClockSync, err)
+		}
+		return c.format(), nil
+	}
+}
+
+// due reports whether the last sync is older than the age, or whether the clock never synced.
+// The caller holds the lock.
+func (c *clock) due(age time.Duration) bool {
+	return c.synced.IsZero() || c.now().Sub(c.synced) >= age
+}
+
+// format returns the corrected local time in milliseconds. The caller holds the lock.
+func (c *clock) format() string {
+	return strconv.FormatInt(c.now().Add(c.offset).UnixMilli(), 10)
+}
+
+// sync measures the offset between the server time and the local time, and the local time of the measure.
+// Half of the round trip time is added to the server time, since the server
+// read its clock (roughly) in the middle of the round trip.
+func (c *clock) sync(ctx context.Context) (time.Duration, time.Time, error) {
+	sent := c.now()
+	serverTime, err := c.fetch(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	received := c.now()
+
+	ms, err := strconv.ParseInt(serverTime, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid server time %q: %w", serverTime, err)
+	}
+
+	rtt := received.Sub(sent)
+	return time.UnixMilli(ms).Add(rtt / 2).Sub(received), received, nil
+}
+
+// WithClockSyncInterval sets how often the offset with the server time is measured again.
+func WithClockSyncInterval(interval time.Duration) Option {
+	return func(bksdk *SDK) error {
+		if interval <= 0 {
+			return fmt.Errorf("invalid clock sync interval %s", interval)
+		}
+		bksdk.syncEvery = interval
+		return nil
+	}
+}
@@ -0,0 +1,328 @@
+// Package decimal provides the exact decimal number used for every amount, rate, fee and credit of the SDK.
+//
+// Floats lose precision on THB amounts and small coin quantities, so requests and responses use Decimal instead.
+// A Decimal decodes from a JSON number or a JSON string, and encodes as a JSON number without trailing zeros
+// (e.g. 1000 instead of 1000.00), as Bitkub requires.
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number: coef * 10^-scale.
+// The zero value is 0. A Decimal is immutable, every operation returns a new value.
+type Decimal struct {
+	coef  *big.Int // Unscaled value, nil means 0
+	scale int32    // Number of digits after the decimal point, never negative
+}
+
+// Zero is the decimal 0.
+var Zero = Decimal{}
+
+var bigTen = big.NewInt(10)
+
+// ErrInvalid is returned when a string is not a valid decimal number.
+var ErrInvalid = errors.New("decimal: invalid number")
+
+// New creates the decimal coef * 10^-scale (e.g. New(12345, 2) is 123.45).
+func New(coef int64, scale int32) Decimal {
+	if scale < 0 {
+		return normalize(new(big.Int).Mul(big.NewInt(coef), pow10(-scale)), 0)
+	}
+	return normalize(big.NewInt(coef), scale)
+}
+
+// FromInt creates a decimal from an integer.
+func FromInt(i int64) Decimal {
+	return New(i, 0)
+}
+
+// FromFloat creates a decimal from the shortest representation of a float (e.g. 0.1 is exactly 0.1).
+func FromFloat(f float64) Decimal {
+	d, err := Parse(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Zero
+	}
+	return d
+}
+
+// Parse parses a decimal from a string such as "123.45", "-0.001", "1e-8" or "1.5E+3".
+func Parse(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, fmt.Errorf("%w: empty string", ErrInvalid)
+	}
+
+	// Split the exponent
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		s = s[:i]
+	}
+
+	// Split the sign
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	// Split the integer and the fraction parts
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Zero, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+
+	// Apply the exponent to the scale
+	scale := int64(len(fracPart)) - exp
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return normalize(coef, int32(scale)), nil
+}
+
+// MustParse is like Parse but panics if s is not a valid decimal.
+// It simplifies the declaration of constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// normalize removes the trailing zeros of the fraction, so every number has a single representation.
+func normalize(coef *big.Int, scale int32) Decimal {
+	if coef.Sign() == 0 {
+		return Zero
+	}
+
+	rem := new(big.Int)
+	for scale > 0 {
+		q, r := new(big.Int).QuoRem(coef, bigTen, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		coef = q
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// pow10 returns 10^n.
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// bigCoef returns the unscaled value, never nil.
+func (d Decimal) bigCoef() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the unscaled value of d at a scale greater than or equal to d.scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	return new(big.Int).Mul(d.bigCoef(), pow10(scale-d.scale))
+}
+
+// align returns the unscaled values of d and d2 at their common scale.
+func align(d, d2 Decimal) (*big.Int, *big.Int, int32) {
+	scale := d.scale
+	if d2.scale > scale {
+		scale = d2.scale
+	}
+	return d.rescale(scale), d2.rescale(scale), scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.bigCoef().Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// IsPositive reports whether d is greater than 0.
+func (d Decimal) IsPositive() bool {
+	return d.Sign() > 0
+}
+
+// IsNegative reports whether d is less than 0.
+func (d Decimal) IsNegative() bool {
+	return d.Sign() < 0
+}
+
+// Cmp compares d and d2 and returns -1, 0 or +1.
+func (d Decimal) Cmp(d2 Decimal) int {
+	a, b, _ := align(d, d2)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and d2 are the same number.
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// LessThan reports whether d < d2.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan reports whether d > d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	a, b, scale := align(d, d2)
+	return normalize(a.Add(a, b), scale)
+}
+
+// Sub returns d - d2.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	a, b, scale := align(d, d2)
+	return normalize(a.Sub(a, b), scale)
+}
+
+// Mul returns d * d2.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return normalize(new(big.Int).Mul(d.bigCoef(), d2.bigCoef()), d.scale+d2.scale)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return normalize(new(big.Int).Neg(d.bigCoef()), d.scale)
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return normalize(new(big.Int).Abs(d.bigCoef()), d.scale)
+}
+
+// Div returns d / d2 rounded half away from zero to the given number of decimal places.
+// It panics if d2 is 0, like an integer division.
+func (d Decimal) Div(d2 Decimal, places int32) Decimal {
+	if d2.IsZero() {
+		panic("decimal: division by zero")
+	}
+
+	// d / d2 = (a * 10^-sa) / (b * 10^-sb), computed with 1 extra digit for the rounding
+	num := new(big.Int).Mul(d.bigCoef(), pow10(places+1+d2.scale))
+	den := new(big.Int).Mul(d2.bigCoef(), pow10(d.scale))
+	q := new(big.Int).Quo(num, den)
+	return Decimal{coef: q, scale: places + 1}.Round(places)
+}
+
+// Round rounds d half away from zero to the given number of decimal places.
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 || d.scale <= places {
+		return d
+	}
+
+	q, r := new(big.Int).QuoRem(d.bigCoef(), pow10(d.scale-places), new(big.Int))
+
+	// Round away from zero when the remainder is at least half of the divisor
+	half := new(big.Int).Mul(big.NewInt(5), pow10(d.scale-places-1))
+	if new(big.Int).Abs(r).Cmp(half) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+	return normalize(q, places)
+}
+
+// Truncate drops the digits after the given number of decimal places (rounding toward zero).
+func (d Decimal) Truncate(places int32) Decimal {
+	if places < 0 || d.scale <= places {
+		return d
+	}
+	return normalize(new(big.Int).Quo(d.bigCoef(), pow10(d.scale-places)), places)
+}
+
+// Scale returns the number of digits after the decimal point, without trailing zeros.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d without exponent and without trailing zeros (e.g. "1000", "0.00012").
+func (d Decimal) String() string {
+	coef := d.bigCoef()
+	if d.scale == 0 {
+		return coef.String()
+	}
+
+	digits := new(big.Int).Abs(coef).String()
+	if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	sign := ""
+	if coef.Sign() < 0 {
+		sign = "-"
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalJSON encodes d as a JSON number without trailing zeros.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes d from a JSON number or a JSON string.
+// null and the empty string decode as 0.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		s, err = strconv.Unquote(s)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalid, data)
+		}
+		if strings.TrimSpace(s) == "" {
+			*d = Zero
+			return nil
+		}
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,70 @@
+package bksdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+)
+
+// dryRunKey is the context key marking a dry run call.
+type dryRunKey struct{}
+
+// WithDryRun sends every PlaceBid and PlaceAsk to the test endpoints (/api/market/place-bid/test
+// and /api/market/place-ask/test), which validate the signed order without creating it.
+// Use DryRun to dry run a single call instead.
+//
+// The test endpoints are v1 endpoints: the order fields are those of the v3 call, but the order is signed
+// in the payload (ts in seconds and sig) instead of the v3 headers, and its symbol is sent as THB_BTC.
+// A dry run does not check the v3 signing, and it may fail for keys only allowed on the v3 endpoints.
+func WithDryRun() Option {
+	return func(bksdk *SDK) error {
+		bksdk.dryRun = true
+		return nil
+	}
+}
+
+// DryRun returns a copy of ctx with which PlaceBidCtx and PlaceAskCtx send the order to the test endpoints,
+// like WithDryRun does for every call. The order is signed with the v1 signing, see WithDryRun.
+func DryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// isDryRun reports whether an order placed with ctx is a dry run.
+func (bksdk *SDK) isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return bksdk.dryRun || dryRun
+}
+
+// placeTest sends an order to a test endpoint. The test endpoints are v1 endpoints:
+// the order is signed in the payload and its symbol is in the v1 format (btc_thb is sent as THB_BTC).
+// A dry run is never retried, since no order is created.
+func (bksdk *SDK) placeTest(ctx context.Context, endpoint string, order any, sym string) (response.PlaceTestResult, error) {
+	// Initialize the response variable
+	var respBody response.PlaceTest
+
+	// Convert the order to JSON, with the symbol in the v1 format
+	fields := make(map[string]json.RawMessage)
+	orderByte, err := json.Marshal(order)
+	if err != nil {
+		return respBody.Result, err
+	}
+	if err := json.Unmarshal(orderByte, &fields); err != nil {
+		return respBody.Result, err
+	}
+	fields["sym"], _ = json.Marshal(formatSymbol(sym, FormatLegacy))
+	reqBodyByte, err := json.Marshal(fields)
+	if err != nil {
+		return respBody.Result, err
+	}
+
+	// Send the request and decode the response body
+	c := call{method: http.MethodPost, endpoint: endpoint, payload: string(reqBodyByte), secure: true, legacy: true}
+	_, err = bksdk.do(ctx, c, &respBody)
+	if err != nil {
+		return respBody.Result, err
+	}
+
+	return respBody.Result, nil
+}
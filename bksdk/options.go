@@ -0,0 +1,69 @@
+package bksdk
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default hosts used when no option overrides them.
+const (
+	DefaultAPIHost = "https://api.bitkub.com"
+	DefaultWSHost  = WS_HOST
+)
+
+// Option configures an SDK instance created with NewWithOptions.
+type Option func(*SDK) error
+
+// WithBaseURL overrides the REST API host (e.g. a staging proxy or a local mock server).
+func WithBaseURL(baseURL string) Option {
+	return func(bksdk *SDK) error {
+		apiHostURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		bksdk.apiHost = apiHostURL
+		return nil
+	}
+}
+
+// WithHTTPClient sets the *http.Client used for every REST call.
+// Its Transport, TLS settings and Timeout are used as they are.
+func WithHTTPClient(client *http.Client) Option {
+	return func(bksdk *SDK) error {
+		bksdk.httpClient = client
+		return nil
+	}
+}
+
+// WithTimeout sets the overall timeout of a single REST call.
+func WithTimeout(timeout time.Duration) Option {
+	return func(bksdk *SDK) error {
+		bksdk.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every REST call.
+func WithUserAgent(userAgent string) Option {
+	return func(bksdk *SDK) error {
+		bksdk.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithWSHost overrides the websocket host used by CreateWsConnection.
+// Stream names are appended to it, so a trailing slash is added when missing.
+func WithWSHost(wsHost string) Option {
+	return func(bksdk *SDK) error {
+		if _, err := url.Parse(wsHost); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(wsHost, "/") {
+			wsHost += "/"
+		}
+		bksdk.wsHost = wsHost
+		return nil
+	}
+}
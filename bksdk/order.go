@@ -0,0 +1,130 @@
+package bksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/request"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+)
+
+// Side is the side of an order.
+type Side string
+
+const (
+	SideBuy  Side = "buy"  // Bid, placed with PlaceBid
+	SideSell Side = "sell" // Ask, placed with PlaceAsk
+)
+
+// OrderType is the type of an order.
+type OrderType string
+
+const (
+	OrderLimit  OrderType = "limit"  // Order at a rate
+	OrderMarket OrderType = "market" // Order at the best rates of the book, its rate is 0
+)
+
+// Order is an order built with NewOrder and placed with Place, e.g.
+//
+//	bksdk.NewOrder().Buy("btc_thb").Limit(rate).Amount(amount).ClientID(id)
+//
+// For a buy order the amount is in the quote currency (THB to spend),
+// for a sell order it is in the base currency (BTC to sell).
+type Order struct {
+	side     Side
+	symbol   string
+	typ      OrderType
+	amount   decimal.Decimal
+	rate     decimal.Decimal
+	clientID string
+}
+
+// NewOrder starts building an order.
+func NewOrder() *Order {
+	return &Order{}
+}
+
+// Buy makes the order a bid on the symbol, in any convention (e.g. btc_thb or THB_BTC).
+func (o *Order) Buy(symbol string) *Order {
+	o.side, o.symbol = SideBuy, symbol
+	return o
+}
+
+// Sell makes the order an ask on the symbol, in any convention (e.g. btc_thb or THB_BTC).
+func (o *Order) Sell(symbol string) *Order {
+	o.side, o.symbol = SideSell, symbol
+	return o
+}
+
+// Limit makes the order a limit order at the rate.
+func (o *Order) Limit(rate decimal.Decimal) *Order {
+	o.typ, o.rate = OrderLimit, rate
+	return o
+}
+
+// Market makes the order a market order, its rate is 0.
+func (o *Order) Market() *Order {
+	o.typ, o.rate = OrderMarket, decimal.Zero
+	return o
+}
+
+// Amount sets the amount of the order, THB to spend for a buy order and the base currency to sell for a sell order.
+func (o *Order) Amount(amount decimal.Decimal) *Order {
+	o.amount = amount
+	return o
+}
+
+// ClientID sets our reference of the order, also used by WithOrderRetry to find an order placed by a previous attempt.
+func (o *Order) ClientID(id string) *Order {
+	o.clientID = id
+	return o
+}
+
+// String describes the order (e.g. "buy limit 1000 btc_thb at 1500000").
+func (o *Order) String() string {
+	if o.typ == OrderMarket {
+		return fmt.Sprintf("%s %s %s %s", o.side, o.typ, o.amount, o.symbol)
+	}
+	return fmt.Sprintf("%s %s %s %s at %s", o.side, o.typ, o.amount, o.symbol, o.rate)
+}
+
+// Validate checks every field of the order, it returns a request.ValidationError listing each invalid field.
+func (o *Order) Validate() error {
+	var errs request.ValidationError
+	if o.side != SideBuy && o.side != SideSell {
+		errs = append(errs, request.FieldError{Field: "side", Message: "must be buy or sell, call Buy or Sell"})
+	}
+
+	// The bid and the ask have the same fields, the symbol may be in any convention
+	bid := request.PlaceBid{Symbol: formatSymbol(o.symbol, FormatV3), Type: string(o.typ), ClientID: o.clientID, Amount: o.amount, Rate: o.rate}
+	var fieldErrs request.ValidationError
+	if err := bid.Validate(); errors.As(err, &fieldErrs) {
+		errs = append(errs, fieldErrs...)
+	} else if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Place validates the order and places it with PlaceBidCtx or PlaceAskCtx according to its side,
+// so WithOrderRetry, WithDryRun and DryRun apply to it.
+func (bksdk *SDK) Place(ctx context.Context, order *Order) (response.PlaceOrderResult, error) {
+	// Validate the order
+	if err := order.Validate(); err != nil {
+		return response.PlaceOrderResult{}, err
+	}
+
+	// Place the order on the endpoint of its side
+	if order.side == SideBuy {
+		result, err := bksdk.PlaceBidCtx(ctx, order.symbol, order.amount, order.rate, string(order.typ), order.clientID)
+		return response.PlaceOrderResult(result), err
+	}
+	result, err := bksdk.PlaceAskCtx(ctx, order.symbol, order.amount, order.rate, string(order.typ), order.clientID)
+	return response.PlaceOrderResult(result), err
+}
@@ -0,0 +1,351 @@
+// Package orderbook maintains a local order book of a symbol from the Bitkub order book websocket stream.
+//
+// The book is bootstrapped from GetBooks, then every bidschanged, askschanged and tradeschanged event
+// replaces the changed side. Bitkub does not number the events, so a gap is detected when the connection
+// is lost or when the book gets crossed (best bid at or above best ask), and the book is resynchronised from GetBooks.
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+)
+
+// DefaultDepth is the number of orders of each side requested from GetBooks to bootstrap the book.
+const DefaultDepth = 100
+
+// ErrUnknownSymbol is returned by Run when the symbol is not listed by GetSymbols.
+var ErrUnknownSymbol = bkerr.ErrUnknownSymbol
+
+// Source is the part of the SDK used by the book, bksdk.SDKEndpoints satisfies it.
+type Source interface {
+	GetSymbolsCtx(ctx context.Context) ([]response.MarketSymbolsResult, error)
+	GetBooksCtx(ctx context.Context, sym string, limit int) (response.MarketBooksResult, error)
+	NewWsClient(ctx context.Context, opts ...bksdk.WsOption) *bksdk.WsClient
+}
+
+// Level is the aggregated orders of a rate.
+type Level struct {
+	Rate   decimal.Decimal // Rate of the level
+	Amount decimal.Decimal // Amount of the level in the base currency (e.g. BTC)
+	Volume decimal.Decimal // Value of the level in the quote currency (e.g. THB)
+}
+
+// Snapshot is a copy of the book at a point in time.
+type Snapshot struct {
+	Symbol    string    // Symbol of the book (e.g. THB_BTC)
+	Bids      []Level   // Bids sorted from the highest rate
+	Asks      []Level   // Asks sorted from the lowest rate
+	UpdatedAt time.Time // Time of the last change
+}
+
+// Option configures a book created with New.
+type Option func(*Book)
+
+// WithDepth sets the number of orders of each side requested from GetBooks to bootstrap the book.
+func WithDepth(depth int) Option {
+	return func(b *Book) {
+		b.depth = depth
+	}
+}
+
+// WithSnapshotHandler sets a function called with a snapshot of the book after every change.
+// It is called from the goroutine running the book, so it must not block.
+func WithSnapshotHandler(handler func(Snapshot)) Option {
+	return func(b *Book) {
+		b.onSnapshot = handler
+	}
+}
+
+// WithWsOptions sets the options of the websocket client of the book.
+func WithWsOptions(opts ...bksdk.WsOption) Option {
+	return func(b *Book) {
+		b.wsOpts = opts
+	}
+}
+
+// Book is the local order book of a symbol. It is safe for concurrent reads while Run updates it.
+type Book struct {
+	source     Source
+	symbol     string
+	depth      int
+	onSnapshot func(Snapshot)
+	wsOpts     []bksdk.WsOption
+
+	mu        sync.RWMutex
+	bids      []Level
+	asks      []Level
+	updatedAt time.Time
+	resyncs   int
+}
+
+// New creates the book of a symbol in any convention (e.g. btc_thb or THB_BTC). Call Run to maintain it.
+func New(source Source, symbol string, opts ...Option) *Book {
+	// The book is named like GetSymbols (THB_BTC)
+	if sym, err := bksdk.ParseSymbol(symbol); err == nil {
+		symbol = sym.Format(bksdk.FormatLegacy)
+	}
+
+	b := &Book{
+		source: source,
+		symbol: strings.ToUpper(symbol),
+		depth:  DefaultDepth,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Run bootstraps the book and keeps it up to date until ctx is done.
+// It returns the context error, or the error that prevented the book from starting.
+func (b *Book) Run(ctx context.Context) error {
+	// Find the pairing id of the symbol, the order book stream is named after it
+	pairingID, err := b.pairingID(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before bootstrapping, so no event is missed between the two
+	client := b.source.NewWsClient(ctx, b.wsOpts...)
+	defer client.Close()
+	if err := client.Subscribe(bksdk.OrderBookStream(pairingID)); err != nil {
+		return err
+	}
+
+	if err := b.resync(ctx); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case event, ok := <-client.OrderBooks():
+			if !ok {
+				return ctx.Err()
+			}
+			if event.PairingID != pairingID || !b.apply(event) {
+				continue
+			}
+
+			// A crossed book means an event was missed
+			if b.crossed() {
+				if err := b.resync(ctx); err != nil && ctx.Err() != nil {
+					return ctx.Err()
+				}
+				continue
+			}
+			b.notify()
+
+		case err, ok := <-client.Errors():
+			if !ok {
+				return ctx.Err()
+			}
+
+			// Events may have been missed while the connection was down
+			wsErr := &bkerr.WsError{}
+			if errors.As(err, &wsErr) && wsErr.Op != "decode" {
+				if err := b.resync(ctx); err != nil && ctx.Err() != nil {
+					return ctx.Err()
+				}
+			}
+		}
+	}
+}
+
+// pairingID returns the id of the symbol from GetSymbols.
+func (b *Book) pairingID(ctx context.Context) (int, error) {
+	symbols, err := b.source.GetSymbolsCtx(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// The symbol of the book may be in any convention
+	want, err := bksdk.ParseSymbol(b.symbol)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownSymbol, b.symbol)
+	}
+	for _, sym := range symbols {
+		if got, err := bksdk.ParseSymbol(sym.Symbol); err == nil && got == want {
+			return sym.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownSymbol, b.symbol)
+}
+
+// resync replaces the book with the orders from GetBooks.
+// A failed resync leaves the book as is, the next gap triggers another one.
+func (b *Book) resync(ctx context.Context) error {
+	books, err := b.source.GetBooksCtx(ctx, b.symbol, b.depth)
+	if err != nil {
+		return err
+	}
+
+	bids := aggregate(books.Bids)
+	asks := aggregate(books.Asks)
+
+	b.mu.Lock()
+	b.bids, b.asks = sortLevels(bids, true), sortLevels(asks, false)
+	b.updatedAt = time.Now()
+	b.resyncs++
+	b.mu.Unlock()
+
+	b.notify()
+	return nil
+}
+
+// apply replaces the side changed by the event, it reports whether the book changed.
+func (b *Book) apply(event response.WsOrderBook) bool {
+	var bids, asks []Level
+	switch event.Event {
+	case response.WsBidsChanged:
+		bids = levels(event.Bids)
+	case response.WsAsksChanged:
+		asks = levels(event.Asks)
+	case response.WsTradesChanged:
+		bids, asks = levels(event.Bids), levels(event.Asks)
+	default:
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if bids != nil {
+		b.bids = sortLevels(bids, true)
+	}
+	if asks != nil {
+		b.asks = sortLevels(asks, false)
+	}
+	b.updatedAt = time.Now()
+	return true
+}
+
+// crossed reports whether the best bid is at or above the best ask.
+func (b *Book) crossed() bool {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	return okBid && okAsk && !bid.Rate.LessThan(ask.Rate)
+}
+
+// notify calls the snapshot handler.
+func (b *Book) notify() {
+	if b.onSnapshot != nil {
+		b.onSnapshot(b.Snapshot())
+	}
+}
+
+// BestBid returns the highest bid, false when there is no bid.
+func (b *Book) BestBid() (Level, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 {
+		return Level{}, false
+	}
+	return b.bids[0], true
+}
+
+// BestAsk returns the lowest ask, false when there is no ask.
+func (b *Book) BestAsk() (Level, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.asks) == 0 {
+		return Level{}, false
+	}
+	return b.asks[0], true
+}
+
+// Spread returns the best ask rate minus the best bid rate, false when a side is empty.
+func (b *Book) Spread() (decimal.Decimal, bool) {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Zero, false
+	}
+	return ask.Rate.Sub(bid.Rate), true
+}
+
+// Depth returns up to n levels of each side, from the best rate.
+func (b *Book) Depth(n int) (bids, asks []Level) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return head(b.bids, n), head(b.asks, n)
+}
+
+// Snapshot returns a copy of the whole book.
+func (b *Book) Snapshot() Snapshot {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return Snapshot{
+		Symbol:    b.symbol,
+		Bids:      head(b.bids, len(b.bids)),
+		Asks:      head(b.asks, len(b.asks)),
+		UpdatedAt: b.updatedAt,
+	}
+}
+
+// Resyncs returns the number of times the book was loaded from GetBooks, including the bootstrap.
+func (b *Book) Resyncs() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.resyncs
+}
+
+// aggregate sums the orders of GetBooks by rate.
+// The decimals have no trailing zeros, so equal rates have the same string.
+func aggregate(entries response.MarketResult) []Level {
+	byRate := make(map[string]int)
+	var result []Level
+	for _, entry := range entries {
+		i, ok := byRate[entry.Rate.String()]
+		if !ok {
+			i = len(result)
+			byRate[entry.Rate.String()] = i
+			result = append(result, Level{Rate: entry.Rate})
+		}
+		result[i].Amount = result[i].Amount.Add(entry.Amount)
+		result[i].Volume = result[i].Volume.Add(entry.Volume)
+	}
+	return result
+}
+
+// levels converts the levels of the stream.
+func levels(stream []response.WsBookLevel) []Level {
+	result := make([]Level, len(stream))
+	for i, level := range stream {
+		result[i] = Level{Rate: level.Rate, Amount: level.Amount, Volume: level.Volume}
+	}
+	return result
+}
+
+// sortLevels sorts bids from the highest rate and asks from the lowest rate.
+func sortLevels(levels []Level, desc bool) []Level {
+	sort.Slice(levels, func(i, j int) bool {
+		if desc {
+			return levels[i].Rate.GreaterThan(levels[j].Rate)
+		}
+		return levels[i].Rate.LessThan(levels[j].Rate)
+	})
+	return levels
+}
+
+// head returns a copy of the first n levels.
+func head(levels []Level, n int) []Level {
+	if n > len(levels) {
+		n = len(levels)
+	}
+	if n < 0 {
+		n = 0
+	}
+	return append([]Level(nil), levels[:n]...)
+}
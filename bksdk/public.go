@@ -1,11 +1,13 @@
 package bksdk
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/naruebaet/bitkub-sdk/bksdk/api"
 	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
@@ -13,22 +15,22 @@ import (
 	"github.com/naruebaet/bitkub-sdk/bksdk/response"
 )
 
+// historyChunkCandles is the number of candles of a single history request, GetHistory splits larger ranges.
+const historyChunkCandles = 1000
+
 // GetStatus retrieves the status from the API.
 func (bksdk *SDK) GetStatus() (response.Status, error) {
+	return bksdk.GetStatusCtx(context.Background())
+}
+
+// GetStatusCtx is like GetStatus but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetStatusCtx(ctx context.Context) (response.Status, error) {
 	// Initialize the response body.
 	var respBody response.Status
 
-	// Generate the target URL.
-	targetURL := bksdk.apiHost.JoinPath(api.Status)
-
-	// Send a GET request to the target URL and retrieve the response body.
-	_, body, errs := bksdk.req.Get(targetURL.String()).End()
-	if errs != nil {
-		return respBody, errs[0]
-	}
-
-	// Unmarshal the response body into the respBody variable.
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.Status}, &respBody)
 	if err != nil {
 		return respBody, err
 	}
@@ -41,54 +43,63 @@ func (bksdk *SDK) GetStatus() (response.Status, error) {
 // Endpoint: /api/servertime
 // Method: GET
 func (bksdk *SDK) GetServerTime() (string, error) {
+	return bksdk.GetServerTimeCtx(context.Background())
+}
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.ServertimeV3)
-
-	// Send a GET request to the target URL
-	resp, timestamp, errs := bksdk.req.Get(targetUrl.String()).End()
-
-	// Check for errors or a non-OK status code
-	if errs != nil || resp.StatusCode != http.StatusOK {
-		return "0", errs[0]
+// GetServerTimeCtx is like GetServerTime but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetServerTimeCtx(ctx context.Context) (string, error) {
+	// Send the request, the body is the bare timestamp so there is nothing to decode
+	timestamp, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.ServertimeV3}, nil)
+	if err != nil {
+		return "0", err
 	}
 
 	// Return the server time
-	return timestamp, nil
+	return string(timestamp), nil
 }
 
 // GetSymbols retrieves the market symbols from the API.
 // Endpoint: /api/market/symbols
 // Method: GET
 func (bksdk *SDK) GetSymbols() ([]response.MarketSymbolsResult, error) {
+	return bksdk.GetSymbolsCtx(context.Background())
+}
+
+// GetSymbolsCtx is like GetSymbols but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetSymbolsCtx(ctx context.Context) ([]response.MarketSymbolsResult, error) {
 	// Initialize the response body
 	var respBody response.MarketSymbols
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.MarketSymbol)
-
-	// Send the HTTP GET request
-	resp, body, errs := bksdk.req.Get(targetURL.String()).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketSymbol}, &respBody)
+	if err != nil {
+		return respBody.Result, err
 	}
 
-	// Check the response status code
-	if resp.StatusCode != http.StatusOK {
-		return respBody.Result, errors.New(body)
-	}
+	return respBody.Result, nil
+}
 
-	// Unmarshal the response body into the respBody variable
-	err := json.Unmarshal([]byte(body), &respBody)
+// GetMarketSymbols retrieves the metadata of every market from the v3 API:
+// the base and quote currencies, the tick size, the lot size and the minimum order value.
+// See SymbolInfo for a cached lookup.
+func (bksdk *SDK) GetMarketSymbols() ([]response.SymbolInfo, error) {
+	return bksdk.GetMarketSymbolsCtx(context.Background())
+}
+
+// GetMarketSymbolsCtx is like GetMarketSymbols but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetMarketSymbolsCtx(ctx context.Context) ([]response.SymbolInfo, error) {
+	// Initialize the response body
+	var respBody response.MarketSymbolsV3
+
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketSymbolV3}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if there is an error in the response body
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
@@ -100,31 +111,23 @@ func (bksdk *SDK) GetSymbols() ([]response.MarketSymbolsResult, error) {
 // If any error occurs during the request or unmarshalling, the response body is empty
 // and the error is returned.
 func (bksdk *SDK) GetTicker(sym string) (map[string]response.MarketTickerData, error) {
+	return bksdk.GetTickerCtx(context.Background(), sym)
+}
+
+// GetTickerCtx is like GetTicker but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetTickerCtx(ctx context.Context, sym string) (map[string]response.MarketTickerData, error) {
 	// Initialize the response body
 	var respBody map[string]response.MarketTickerData
 
-	// Build the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.MarketTicker)
-
 	// Build the query parameters
 	queryValues := url.Values{}
 	if sym != "" {
-		queryValues.Add("sym", sym)
-	}
-
-	// Make the GET request
-	resp, body, errs := bksdk.req.Get(targetURL.String() + "?" + queryValues.Encode()).End()
-	if errs != nil {
-		return respBody, errs[0]
-	}
-
-	// Check the response status code
-	if resp.StatusCode != http.StatusOK {
-		return respBody, errors.New(body)
+		queryValues.Add("sym", formatSymbol(sym, FormatStream))
 	}
 
-	// Unmarshal the response body into the respBody struct
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketTicker, query: queryValues}, &respBody)
 	if err != nil {
 		return respBody, err
 	}
@@ -141,39 +144,26 @@ func (bksdk *SDK) GetTicker(sym string) (map[string]response.MarketTickerData, e
 // - response.MarketTrades: The response body containing the recent trades
 // - error: An error if the request fails or if the response body cannot be parsed
 func (bksdk *SDK) GetTrade(sym string, limit int) (response.MarketTradesResult, error) {
+	return bksdk.GetTradeCtx(context.Background(), sym, limit)
+}
+
+// GetTradeCtx is like GetTrade but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetTradeCtx(ctx context.Context, sym string, limit int) (response.MarketTradesResult, error) {
 	// Initialize the response body
 	var respBody response.MarketTrades
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.MarketTrades)
-
 	// Create the query parameters
 	queryValues := url.Values{}
-	queryValues.Add("sym", sym)
+	queryValues.Add("sym", formatSymbol(sym, FormatStream))
 	queryValues.Add("lmt", strconv.Itoa(limit))
 
-	// Make the GET request
-	resp, body, errs := bksdk.req.Get(targetURL.String() + "?" + queryValues.Encode()).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Check the response status code
-	if resp.StatusCode != http.StatusOK {
-		return respBody.Result, errors.New(body)
-	}
-
-	// Parse the response body
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketTrades, query: queryValues}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check for any errors in the response body
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response body and nil error
 	return respBody.Result, nil
 }
@@ -189,39 +179,26 @@ func (bksdk *SDK) GetTrade(sym string, limit int) (response.MarketTradesResult,
 // - response.MarketBids: The response body containing the market bids
 // - error: An error if any occurred during the request or response handling
 func (bksdk *SDK) GetBids(sym string, limit int) (response.MarketResult, error) {
+	return bksdk.GetBidsCtx(context.Background(), sym, limit)
+}
+
+// GetBidsCtx is like GetBids but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetBidsCtx(ctx context.Context, sym string, limit int) (response.MarketResult, error) {
 	// Initialize the response body
 	var respBody response.MarketBids
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketBids)
-
 	// Create the query parameters
 	queryValues := url.Values{}
-	queryValues.Add("sym", sym)
+	queryValues.Add("sym", formatSymbol(sym, FormatStream))
 	queryValues.Add("lmt", strconv.Itoa(limit))
 
-	// Send the GET request and handle the response
-	resp, body, errs := bksdk.req.Get(targetUrl.String() + "?" + queryValues.Encode()).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Check the response status code
-	if resp.StatusCode != http.StatusOK {
-		return respBody.Result, errors.New(body)
-	}
-
-	// Unmarshal the response body into the respBody variable
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketBids, query: queryValues}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the respBody contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response body and nil error
 	return respBody.Result, nil
 }
@@ -230,39 +207,26 @@ func (bksdk *SDK) GetBids(sym string, limit int) (response.MarketResult, error)
 // It makes a GET request to the /api/market/asks endpoint.
 // Returns the response body as a MarketAsks struct and any error encountered.
 func (bksdk *SDK) GetAsks(sym string, limit int) (response.MarketResult, error) {
+	return bksdk.GetAsksCtx(context.Background(), sym, limit)
+}
+
+// GetAsksCtx is like GetAsks but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetAsksCtx(ctx context.Context, sym string, limit int) (response.MarketResult, error) {
 	// Initialize the response body
 	var respBody response.MarketAsks
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketAsks)
-
 	// Create the query parameters
 	queryValues := url.Values{}
-	queryValues.Add("sym", sym)
+	queryValues.Add("sym", formatSymbol(sym, FormatStream))
 	queryValues.Add("lmt", strconv.Itoa(limit))
 
-	// Send the GET request and retrieve the response
-	resp, body, errs := bksdk.req.Get(targetUrl.String() + "?" + queryValues.Encode()).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Check if the response status code is not OK
-	if resp.StatusCode != http.StatusOK {
-		return respBody.Result, errors.New(body)
-	}
-
-	// Unmarshal the response body into the respBody variable
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketAsks, query: queryValues}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the respBody.Error field is not zero
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response body and no error
 	return respBody.Result, nil
 }
@@ -276,39 +240,26 @@ Query Parameters:
 	lmt int - Number of limit to query open sell orders
 */
 func (bksdk *SDK) GetBooks(sym string, limit int) (response.MarketBooksResult, error) {
+	return bksdk.GetBooksCtx(context.Background(), sym, limit)
+}
+
+// GetBooksCtx is like GetBooks but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetBooksCtx(ctx context.Context, sym string, limit int) (response.MarketBooksResult, error) {
 	// Initialize response body
 	var respBody response.MarketBooks
 
-	// Construct target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketBooks)
-
 	// Set query parameters
 	queryValues := url.Values{}
-	queryValues.Add("sym", sym)
+	queryValues.Add("sym", formatSymbol(sym, FormatStream))
 	queryValues.Add("lmt", strconv.Itoa(limit))
 
-	// Send GET request to the target URL with query parameters
-	resp, body, errs := bksdk.req.Get(targetUrl.String() + "?" + queryValues.Encode()).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Check response status code
-	if resp.StatusCode != http.StatusOK {
-		return respBody.Result, errors.New(body)
-	}
-
-	// Unmarshal response body into respBody
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketBooks, query: queryValues}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if respBody contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return respBody and nil error if everything is successful
 	return respBody.Result, nil
 }
@@ -324,30 +275,22 @@ func (bksdk *SDK) GetBooks(sym string, limit int) (response.MarketBooksResult, e
 // - response.MarketDepth: The market depth response
 // - error: Any error that occurred during the request
 func (bksdk *SDK) GetDepth(sym string, limit int) (response.MarketDepth, error) {
+	return bksdk.GetDepthCtx(context.Background(), sym, limit)
+}
+
+// GetDepthCtx is like GetDepth but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetDepthCtx(ctx context.Context, sym string, limit int) (response.MarketDepth, error) {
 	// Initialize the response body
 	var respBody response.MarketDepth
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketDepth)
-
 	// Create a query string with the sym and lmt parameters
 	queryValues := url.Values{}
-	queryValues.Add("sym", sym)
+	queryValues.Add("sym", formatSymbol(sym, FormatStream))
 	queryValues.Add("lmt", strconv.Itoa(limit))
 
-	// Send the request and retrieve the response
-	resp, body, errs := bksdk.req.Get(targetUrl.String() + "?" + queryValues.Encode()).End()
-	if errs != nil {
-		return respBody, errs[0]
-	}
-
-	// Check if the response status code is not OK
-	if resp.StatusCode != http.StatusOK {
-		return respBody, errors.New(body)
-	}
-
-	// Unmarshal the response body into the respBody variable
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketDepth, query: queryValues}, &respBody)
 	if err != nil {
 		return respBody, err
 	}
@@ -362,48 +305,93 @@ func (bksdk *SDK) GetDepth(sym string, limit int) (response.MarketDepth, error)
 // Method: GET
 //
 // Parameters:
-// - symbol: string - The symbol (e.g. BTC_THB)
+// - symbol: string - The symbol in any convention, sent as BTC_THB (see ParseSymbol)
 // - resolution: string - Chart resolution (1, 5, 15, 60, 240, 1D)
-// - from: int - Timestamp of the starting time (e.g. 1633424427)
-// - to: int - Timestamp of the ending time (e.g. 1633427427)
+// - from: time.Time - Start of the range
+// - to: time.Time - End of the range
+//
+// A range of more than historyChunkCandles candles is split into several requests,
+// whose candles are stitched in time order without duplicates.
 //
 // Returns:
-// - response.TradingviewHistory: the trading view history
-// - error: if there was an error retrieving the history
-func (bksdk *SDK) GetHistory(symbol string, resolution string, from int, to int) (response.TradingviewHistory, error) {
-	// Initialize the response body
-	var respBody response.TradingviewHistory
+// - []response.Candle: the candles sorted by time
+// - error: bkerr.ErrNoData if the range has no candle, a *bkerr.HistoryError for another status than ok,
+// or if there was an error retrieving the history
+func (bksdk *SDK) GetHistory(symbol string, resolution string, from, to time.Time) ([]response.Candle, error) {
+	return bksdk.GetHistoryCtx(context.Background(), symbol, resolution, from, to)
+}
 
-	// Validate the resolution
+// GetHistoryCtx is like GetHistory but sends the requests with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) GetHistoryCtx(ctx context.Context, symbol string, resolution string, from, to time.Time) ([]response.Candle, error) {
+	// Validate the resolution and the range
 	resl, err := request.ValidateResolution(resolution)
 	if err != nil {
-		return respBody, err
+		return nil, err
+	}
+	interval, _ := request.ResolutionDuration(resl)
+	if to.Before(from) {
+		return nil, errors.New("Invalid range")
 	}
 
-	// Build the target URL and query parameters
-	targetURL := bksdk.apiHost.JoinPath(api.TradingviewHistory)
-	queryValues := url.Values{}
-	queryValues.Add("sym", symbol)
-	queryValues.Add("resolution", resl)
-	queryValues.Add("from", strconv.Itoa(from))
-	queryValues.Add("to", strconv.Itoa(to))
-
-	// Send the GET request
-	resp, body, errs := bksdk.req.Get(targetURL.String() + "?" + queryValues.Encode()).End()
-	if errs != nil {
-		return respBody, errs[0]
+	// Fetch the range by chunks, the bounds of the chunks are inclusive
+	var candles []response.Candle
+	seen := make(map[int64]bool)
+	for start := from; !start.After(to); {
+		end := start.Add(historyChunkCandles * interval)
+		if end.After(to) {
+			end = to
+		}
+
+		chunk, err := bksdk.getHistory(ctx, symbol, resl, start, end)
+		if err != nil && !errors.Is(err, bkerr.ErrNoData) {
+			return nil, err
+		}
+
+		// Skip the candles already returned by the previous chunk
+		for _, candle := range chunk {
+			if !seen[candle.Time.Unix()] {
+				seen[candle.Time.Unix()] = true
+				candles = append(candles, candle)
+			}
+		}
+		start = end.Add(time.Second)
 	}
 
-	// Check the response status code
-	if resp.StatusCode != http.StatusOK {
-		return respBody, errors.New(body)
+	if len(candles) == 0 {
+		return nil, bkerr.ErrNoData
 	}
 
-	// Unmarshal the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	sort.Slice(candles, func(i, j int) bool {
+		return candles[i].Time.Before(candles[j].Time)
+	})
+	return candles, nil
+}
+
+// getHistory sends a single history request and converts its status into an error.
+func (bksdk *SDK) getHistory(ctx context.Context, symbol, resolution string, from, to time.Time) ([]response.Candle, error) {
+	// Initialize the response body
+	var respBody response.TradingviewHistory
+
+	queryValues := url.Values{}
+	queryValues.Add("sym", formatSymbol(symbol, FormatHistory))
+	queryValues.Add("resolution", resolution)
+	queryValues.Add("from", strconv.FormatInt(from.Unix(), 10))
+	queryValues.Add("to", strconv.FormatInt(to.Unix(), 10))
+
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.TradingviewHistory, query: queryValues}, &respBody)
 	if err != nil {
-		return respBody, err
+		return nil, err
 	}
 
-	return respBody, nil
+	// A no_data response looks like a success without the status
+	switch respBody.S {
+	case response.HistoryOK:
+		return respBody.Candles(), nil
+	case response.HistoryNoData:
+		return nil, bkerr.ErrNoData
+	default:
+		return nil, &bkerr.HistoryError{Status: respBody.S, Message: respBody.Errmsg}
+	}
 }
@@ -0,0 +1,136 @@
+package bksdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+)
+
+// RateLimit is the token bucket configuration of an endpoint group.
+type RateLimit struct {
+	Rate  float64 // Requests per second, 0 disables the limiter of the group
+	Burst int     // Requests that can be sent at once after an idle period
+}
+
+// DefaultRateLimits follows the request-per-second limits published by Bitkub for each endpoint group.
+// The depth endpoint has a lower limit than the other market data endpoints.
+var DefaultRateLimits = map[api.Group]RateLimit{
+	api.GroupMarketData: {Rate: 100, Burst: 100},
+	api.GroupDepth:      {Rate: 10, Burst: 10},
+	api.GroupTrading:    {Rate: 150, Burst: 150},
+	api.GroupWallet:     {Rate: 150, Burst: 150},
+}
+
+// RateLimitStats holds the metrics of the limiter of an endpoint group.
+type RateLimitStats struct {
+	Requests  int64         // Requests that went through the limiter
+	Throttled int64         // Requests that had to wait for a token
+	WaitTime  time.Duration // Total time spent waiting for a token
+}
+
+// WithRateLimit overrides the limit of an endpoint group.
+// A zero Rate disables the limiter of the group.
+func WithRateLimit(group api.Group, limit RateLimit) Option {
+	return func(bksdk *SDK) error {
+		bksdk.rateLimits[group] = limit
+		return nil
+	}
+}
+
+// tokenBucket is a concurrency-safe token bucket limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token, blocking until one is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+
+	// Refill the bucket with the tokens earned since the last call
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	// Reserve a token, a negative balance is the queue of waiting requests
+	b.tokens--
+	b.stats.Requests++
+	if b.tokens >= 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.stats.Throttled++
+	b.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// Give the reserved token back
+		b.mu.Lock()
+		b.tokens++
+		b.stats.WaitTime += time.Since(now)
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		b.mu.Lock()
+		b.stats.WaitTime += delay
+		b.mu.Unlock()
+		return nil
+	}
+}
+
+// snapshot returns a copy of the metrics.
+func (b *tokenBucket) snapshot() RateLimitStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// newLimiters creates a token bucket for every enabled group.
+func newLimiters(limits map[api.Group]RateLimit) map[api.Group]*tokenBucket {
+	limiters := make(map[api.Group]*tokenBucket, len(limits))
+	for group, limit := range limits {
+		if limit.Rate > 0 {
+			limiters[group] = newTokenBucket(limit)
+		}
+	}
+	return limiters
+}
+
+// waitRateLimit blocks until the group of the endpoint allows one more request.
+func (bksdk *SDK) waitRateLimit(ctx context.Context, endpoint string) error {
+	limiter, ok := bksdk.limiters[api.GroupOf(endpoint)]
+	if !ok {
+		return nil
+	}
+	return limiter.wait(ctx)
+}
+
+// RateLimitStats returns the metrics of the limiter of every enabled endpoint group.
+func (bksdk *SDK) RateLimitStats() map[api.Group]RateLimitStats {
+	stats := make(map[api.Group]RateLimitStats, len(bksdk.limiters))
+	for group, limiter := range bksdk.limiters {
+		stats[group] = limiter.snapshot()
+	}
+	return stats
+}
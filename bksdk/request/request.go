@@ -2,9 +2,11 @@ package request
 
 import (
 	"errors"
+	"sort"
 	"strings"
+	"time"
 
-	validate "github.com/go-playground/validator/v10"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
 )
 
 var resolutions = map[string]string{
@@ -27,41 +29,91 @@ func ValidateResolution(value string) (string, error) {
 	return resolution, nil
 }
 
+// resolutionDurations is the interval of each resolution.
+var resolutionDurations = map[string]time.Duration{
+	"1":   time.Minute,
+	"5":   5 * time.Minute,
+	"15":  15 * time.Minute,
+	"60":  time.Hour,
+	"240": 4 * time.Hour,
+	"1D":  24 * time.Hour,
+}
+
+// ResolutionDuration returns the interval of a resolution (e.g. 15 minutes for "15").
+// If the resolution is not valid, it returns an error.
+func ResolutionDuration(value string) (time.Duration, error) {
+	resolution, err := ValidateResolution(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return resolutionDurations[resolution], nil
+}
+
 type PlaceBid struct {
-	Symbol   string  `json:"sym"`
-	Type     string  `json:"typ" validate:"oneof=limit market"`
-	ClientID string  `json:"client_id"`
-	Amount   float64 `json:"amt"`
-	Rate     float64 `json:"rat"`
+	Symbol   string          `json:"sym" validate:"required"`
+	Type     string          `json:"typ" validate:"oneof=limit market"`
+	ClientID string          `json:"client_id"`
+	Amount   decimal.Decimal `json:"amt"`
+	Rate     decimal.Decimal `json:"rat"`
 }
 
-// validate type of bid
+// validate the symbol, type, amount and rate of the bid, every invalid field is listed in a ValidationError
 func (p *PlaceBid) Validate() error {
-	validate := validate.New()
-	return validate.Struct(p)
+	return validateOrder(validateStruct(p), p.Symbol, p.Type, p.Amount, p.Rate).result()
 }
 
 type PlaceAsk struct {
-	Symbol   string  `json:"sym"`
-	Type     string  `json:"typ" validate:"oneof=limit market"`
-	ClientID string  `json:"client_id"`
-	Amount   float64 `json:"amt"`
-	Rate     float64 `json:"rat"`
+	Symbol   string          `json:"sym" validate:"required"`
+	Type     string          `json:"typ" validate:"oneof=limit market"`
+	ClientID string          `json:"client_id"`
+	Amount   decimal.Decimal `json:"amt"`
+	Rate     decimal.Decimal `json:"rat"`
 }
 
+// validate the symbol, type, amount and rate of the ask, every invalid field is listed in a ValidationError
 func (p *PlaceAsk) Validate() error {
-	validate := validate.New()
-	return validate.Struct(p)
+	return validateOrder(validateStruct(p), p.Symbol, p.Type, p.Amount, p.Rate).result()
+}
+
+type PlaceAskByFiat struct {
+	Symbol string          `json:"sym" validate:"required"`
+	Amount decimal.Decimal `json:"amt"`
+	Rate   decimal.Decimal `json:"rat"`
+	Type   string          `json:"typ" validate:"oneof=limit market"`
+}
+
+// validate the type of the ask, the fiat amount, and the rate of a limit ask
+func (p *PlaceAskByFiat) Validate() error {
+	errs := validateStruct(p)
+	if !p.Amount.IsPositive() {
+		errs = append(errs, FieldError{Field: "amt", Message: "must be positive"})
+	}
+	if p.Type == "limit" && !p.Rate.IsPositive() {
+		errs = append(errs, FieldError{Field: "rat", Message: "must be positive for a limit order"})
+	}
+
+	return errs.result()
 }
 
 type CancelOrder struct {
 	Symbol string `json:"sym"`
 	ID     string `json:"id"`
-	Side   string `json:"sd" oneof:"buy sell"`
+	Side   string `json:"sd" validate:"omitempty,oneof=buy sell"`
 	Hash   string `json:"hash"`
 }
 
+// validate that the order is given by its hash, or by its symbol, id and side
 func (p *CancelOrder) Validate() error {
-	validate := validate.New()
-	return validate.Struct(p)
+	errs := validateStruct(p)
+	if p.Hash == "" {
+		for field, value := range map[string]string{"sym": p.Symbol, "id": p.ID, "sd": p.Side} {
+			if value == "" {
+				errs = append(errs, FieldError{Field: field, Message: "is required without hash"})
+			}
+		}
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	}
+
+	return errs.result()
 }
@@ -0,0 +1,113 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	validate "github.com/go-playground/validator/v10"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+)
+
+// symbolFormat is the format of the symbols of the v3 endpoints, base currency first (e.g. btc_thb).
+var symbolFormat = regexp.MustCompile(`^[a-z0-9]+_[a-z0-9]+$`)
+
+// FieldError is the error of a single field of a request.
+type FieldError struct {
+	Field   string // JSON name of the field (e.g. amt)
+	Message string // What is wrong with the field
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every invalid field of a request.
+// Use errors.As to read the fields, it is returned before the request is sent.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	messages := make([]string, len(e))
+	for i, field := range e {
+		messages[i] = field.Error()
+	}
+	return "Invalid request: " + strings.Join(messages, ", ")
+}
+
+// Field returns the error of a field, false when the field is valid.
+func (e ValidationError) Field(name string) (FieldError, bool) {
+	for _, field := range e {
+		if field.Field == name {
+			return field, true
+		}
+	}
+	return FieldError{}, false
+}
+
+// validator checks the struct tags, it reports the fields by their JSON name.
+var validator = func() *validate.Validate {
+	v := validate.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		return name
+	})
+	return v
+}()
+
+// validateStruct checks the struct tags of a request and returns the invalid fields.
+func validateStruct(s any) ValidationError {
+	err := validator.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrors validate.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return ValidationError{{Field: "", Message: err.Error()}}
+	}
+
+	var result ValidationError
+	for _, fe := range fieldErrors {
+		message := fmt.Sprintf("failed on %s", fe.Tag())
+		switch fe.Tag() {
+		case "required":
+			message = "is required"
+		case "oneof":
+			message = fmt.Sprintf("must be one of %s, got %q", strings.ReplaceAll(fe.Param(), " ", ", "), fe.Value())
+		}
+		result = append(result, FieldError{Field: fe.Field(), Message: message})
+	}
+	return result
+}
+
+// validateOrder checks the fields of an order placed on the v3 endpoints:
+// the symbol format, a positive amount, a positive rate for a limit order and a zero rate for a market order.
+func validateOrder(errs ValidationError, symbol, typ string, amount, rate decimal.Decimal) ValidationError {
+	if _, failed := errs.Field("sym"); !failed && !symbolFormat.MatchString(symbol) {
+		errs = append(errs, FieldError{Field: "sym", Message: fmt.Sprintf("must be in the format base_quote in lower case (e.g. btc_thb), got %q", symbol)})
+	}
+	if !amount.IsPositive() {
+		errs = append(errs, FieldError{Field: "amt", Message: "must be positive"})
+	}
+	switch typ {
+	case "limit":
+		if !rate.IsPositive() {
+			errs = append(errs, FieldError{Field: "rat", Message: "must be positive for a limit order"})
+		}
+	case "market":
+		if !rate.IsZero() {
+			errs = append(errs, FieldError{Field: "rat", Message: "must be 0 for a market order"})
+		}
+	}
+	return errs
+}
+
+// result returns errs as an error, nil when there is no invalid field.
+func (e ValidationError) result() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
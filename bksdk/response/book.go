@@ -0,0 +1,105 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+)
+
+// BookEntry is an open order of the order book.
+// Bitkub sends it as the tuple [order id, timestamp, volume, rate, amount].
+type BookEntry struct {
+	OrderID   string          // Order id
+	Timestamp Timestamp       // Time the order was placed, in seconds
+	Volume    decimal.Decimal // Value of the order in the quote currency (e.g. THB)
+	Rate      decimal.Decimal // Rate of the order
+	Amount    decimal.Decimal // Amount of the order in the base currency (e.g. BTC)
+	Raw       [5]any          `json:"-"` // Tuple as sent by Bitkub
+}
+
+// UnmarshalJSON decodes e from the tuple [order id, timestamp, volume, rate, amount].
+func (e *BookEntry) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) < 5 {
+		return fmt.Errorf("book entry: expected 5 fields, got %d", len(fields))
+	}
+
+	var orderID StringOrNumber
+	if err := json.Unmarshal(fields[0], &orderID); err != nil {
+		return fmt.Errorf("book entry order id: %w", err)
+	}
+	e.OrderID = string(orderID)
+
+	if err := json.Unmarshal(fields[1], &e.Timestamp); err != nil {
+		return fmt.Errorf("book entry timestamp: %w", err)
+	}
+
+	if err := json.Unmarshal(fields[2], &e.Volume); err != nil {
+		return fmt.Errorf("book entry volume: %w", err)
+	}
+	if err := json.Unmarshal(fields[3], &e.Rate); err != nil {
+		return fmt.Errorf("book entry rate: %w", err)
+	}
+	if err := json.Unmarshal(fields[4], &e.Amount); err != nil {
+		return fmt.Errorf("book entry amount: %w", err)
+	}
+
+	// Keep the raw tuple for the callers of the previous [5]any form
+	return json.Unmarshal(data, &e.Raw)
+}
+
+// MarshalJSON encodes e back to its tuple form.
+func (e BookEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Raw)
+}
+
+// Trade is an executed trade.
+// Bitkub sends it as the tuple [timestamp, rate, amount, side].
+type Trade struct {
+	Timestamp Timestamp       // Time of the trade, in seconds
+	Rate      decimal.Decimal // Rate of the trade
+	Amount    decimal.Decimal // Amount traded in the base currency (e.g. BTC)
+	Side      string          // Side of the taker in lower case: buy or sell
+	Raw       [4]any          `json:"-"` // Tuple as sent by Bitkub
+}
+
+// UnmarshalJSON decodes t from the tuple [timestamp, rate, amount, side].
+func (t *Trade) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) < 4 {
+		return fmt.Errorf("trade: expected 4 fields, got %d", len(fields))
+	}
+
+	if err := json.Unmarshal(fields[0], &t.Timestamp); err != nil {
+		return fmt.Errorf("trade timestamp: %w", err)
+	}
+
+	if err := json.Unmarshal(fields[1], &t.Rate); err != nil {
+		return fmt.Errorf("trade rate: %w", err)
+	}
+	if err := json.Unmarshal(fields[2], &t.Amount); err != nil {
+		return fmt.Errorf("trade amount: %w", err)
+	}
+
+	var side string
+	if err := json.Unmarshal(fields[3], &side); err != nil {
+		return fmt.Errorf("trade side: %w", err)
+	}
+	t.Side = strings.ToLower(side)
+
+	// Keep the raw tuple for the callers of the previous [4]any form
+	return json.Unmarshal(data, &t.Raw)
+}
+
+// MarshalJSON encodes t back to its tuple form.
+func (t Trade) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Raw)
+}
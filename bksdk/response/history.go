@@ -0,0 +1,47 @@
+package response
+
+import (
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+)
+
+// Statuses of the TradingView history.
+const (
+	HistoryOK     = "ok"
+	HistoryNoData = "no_data"
+)
+
+// Candle is the OHLCV of an interval of the TradingView history.
+type Candle struct {
+	Time   time.Time // Start of the interval
+	Open   decimal.Decimal
+	High   decimal.Decimal
+	Low    decimal.Decimal
+	Close  decimal.Decimal
+	Volume decimal.Decimal
+}
+
+// Candles returns the candles of the parallel arrays of the history, in the order of the response.
+// A candle missing from one of the arrays is left out.
+func (h TradingviewHistory) Candles() []Candle {
+	n := len(h.T)
+	for _, values := range [][]decimal.Decimal{h.O, h.H, h.L, h.C, h.V} {
+		if len(values) < n {
+			n = len(values)
+		}
+	}
+
+	candles := make([]Candle, n)
+	for i := range candles {
+		candles[i] = Candle{
+			Time:   time.Unix(int64(h.T[i]), 0),
+			Open:   h.O[i],
+			High:   h.H[i],
+			Low:    h.L[i],
+			Close:  h.C[i],
+			Volume: h.V[i],
+		}
+	}
+	return candles
+}
@@ -1,5 +1,7 @@
 package response
 
+import "github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+
 // /api/status
 type Status []struct {
 	Name    string `json:"name"`
@@ -13,7 +15,16 @@ type MarketTrades struct {
 	Result MarketTradesResult `json:"result"`
 }
 
-type MarketTradesResult [][4]any
+type MarketTradesResult []Trade
+
+// Raw returns the trades in the tuple form sent by Bitkub.
+func (r MarketTradesResult) Raw() [][4]any {
+	raw := make([][4]any, len(r))
+	for i, trade := range r {
+		raw[i] = trade.Raw
+	}
+	return raw
+}
 
 // /api/market/bids
 type MarketBids struct {
@@ -27,7 +38,16 @@ type MarketAsks struct {
 	Result MarketResult `json:"result"`
 }
 
-type MarketResult [][5]any
+type MarketResult []BookEntry
+
+// Raw returns the entries in the tuple form sent by Bitkub.
+func (r MarketResult) Raw() [][5]any {
+	raw := make([][5]any, len(r))
+	for i, entry := range r {
+		raw[i] = entry.Raw
+	}
+	return raw
+}
 
 // /api/market/books
 type MarketBooks struct {
@@ -36,39 +56,40 @@ type MarketBooks struct {
 }
 
 type MarketBooksResult struct {
-	Bids [][5]any `json:"bids"`
-	Asks [][5]any `json:"asks"`
+	Bids MarketResult `json:"bids"`
+	Asks MarketResult `json:"asks"`
 }
 
 // /tradingview/history
 type TradingviewHistory struct {
-	C []float64 `json:"c"`
-	H []float64 `json:"h"`
-	L []float64 `json:"l"`
-	O []float64 `json:"o"`
-	S string    `json:"s"`
-	T []int     `json:"t"`
-	V []float64 `json:"v"`
+	C      []decimal.Decimal `json:"c"`
+	H      []decimal.Decimal `json:"h"`
+	L      []decimal.Decimal `json:"l"`
+	O      []decimal.Decimal `json:"o"`
+	S      string            `json:"s"`
+	T      []int             `json:"t"`
+	V      []decimal.Decimal `json:"v"`
+	Errmsg string            `json:"errmsg"`
 }
 
 // /api/market/depth
 type MarketDepth struct {
-	Asks [][]float64 `json:"asks"`
-	Bids [][]float64 `json:"bids"`
+	Asks [][]decimal.Decimal `json:"asks"`
+	Bids [][]decimal.Decimal `json:"bids"`
 }
 
 // /api/market/ticker
 type MarketTickerData struct {
-	ID            int     `json:"id"`
-	Last          float64 `json:"last"`
-	LowestAsk     float64 `json:"lowestAsk"`
-	HighestBid    float64 `json:"highestBid"`
-	PercentChange float64 `json:"percentChange"`
-	BaseVolume    float64 `json:"baseVolume"`
-	QuoteVolume   float64 `json:"quoteVolume"`
-	IsFrozen      int     `json:"isFrozen"`
-	High24Hr      float64 `json:"high24hr"`
-	Low24Hr       float64 `json:"low24hr"`
+	ID            int             `json:"id"`
+	Last          decimal.Decimal `json:"last"`
+	LowestAsk     decimal.Decimal `json:"lowestAsk"`
+	HighestBid    decimal.Decimal `json:"highestBid"`
+	PercentChange decimal.Decimal `json:"percentChange"`
+	BaseVolume    decimal.Decimal `json:"baseVolume"`
+	QuoteVolume   decimal.Decimal `json:"quoteVolume"`
+	IsFrozen      int             `json:"isFrozen"`
+	High24Hr      decimal.Decimal `json:"high24hr"`
+	Low24Hr       decimal.Decimal `json:"low24hr"`
 }
 
 // /api/market/symbols
@@ -83,6 +104,35 @@ type MarketSymbolsResult struct {
 	Info   string `json:"info"`
 }
 
+// /api/v3/market/symbols
+type MarketSymbolsV3 struct {
+	Error  int          `json:"error"`
+	Result []SymbolInfo `json:"result"`
+}
+
+// SymbolInfo is the metadata of a market, with the rules its orders must follow.
+type SymbolInfo struct {
+	Symbol                string          `json:"symbol"` // Symbol of the market (e.g. BTC_THB)
+	Name                  string          `json:"name"`
+	Description           string          `json:"description"`
+	PairingID             int             `json:"pairing_id"`
+	Status                string          `json:"status"` // active when the market is open
+	MarketSegment         string          `json:"market_segment"`
+	BaseAsset             string          `json:"base_asset"` // Base currency (e.g. BTC)
+	BaseAssetScale        int             `json:"base_asset_scale"`
+	QuoteAsset            string          `json:"quote_asset"` // Quote currency (e.g. THB)
+	QuoteAssetScale       int             `json:"quote_asset_scale"`
+	PriceScale            int             `json:"price_scale"`
+	PriceStep             decimal.Decimal `json:"price_step"`     // Tick size, every rate is a multiple of it
+	VolumeStep            decimal.Decimal `json:"volume_step"`    // Lot size, every amount of the base currency is a multiple of it
+	MinQuoteSize          decimal.Decimal `json:"min_quote_size"` // Minimum value of an order in the quote currency
+	BuyPriceGapAsPercent  float64         `json:"buy_price_gap_as_percent"`
+	SellPriceGapAsPercent float64         `json:"sell_price_gap_as_percent"`
+	FreezeBuy             bool            `json:"freeze_buy"`
+	FreezeSell            bool            `json:"freeze_sell"`
+	FreezeCancel          bool            `json:"freeze_cancel"`
+}
+
 // /api/v3/market/my-open-orders
 type MyOpenOrder struct {
 	Error  int                 `json:"error"`
@@ -90,19 +140,19 @@ type MyOpenOrder struct {
 }
 
 type MyOpenOrderResult struct {
-	ID       string  `json:"id"`
-	Hash     string  `json:"hash"`
-	Side     string  `json:"side"`
-	Type     string  `json:"type"`
-	Rate     int     `json:"rate"`
-	Fee      float64 `json:"fee"`
-	Credit   float64 `json:"credit"`
-	Amount   float64 `json:"amount"`
-	Receive  int     `json:"receive"`
-	ParentID int     `json:"parent_id"`
-	SuperID  int     `json:"super_id"`
-	ClientID string  `json:"client_id"`
-	Ts       int     `json:"ts"`
+	ID       string          `json:"id"`
+	Hash     string          `json:"hash"`
+	Side     string          `json:"side"`
+	Type     string          `json:"type"`
+	Rate     decimal.Decimal `json:"rate"`
+	Fee      decimal.Decimal `json:"fee"`
+	Credit   decimal.Decimal `json:"credit"`
+	Amount   decimal.Decimal `json:"amount"`
+	Receive  decimal.Decimal `json:"receive"`
+	ParentID StringOrNumber  `json:"parent_id"`
+	SuperID  StringOrNumber  `json:"super_id"`
+	ClientID string          `json:"client_id"`
+	Ts       Timestamp       `json:"ts"`
 }
 
 // /api/v3/market/my-order-history
@@ -113,20 +163,21 @@ type MyOrderHistory struct {
 }
 
 type MyOrderHistoryResult struct {
-	TxnID         string `json:"txn_id"`
-	OrderID       string `json:"order_id"`
-	Hash          string `json:"hash"`
-	ParentOrderID string `json:"parent_order_id"`
-	SuperOrderID  string `json:"super_order_id"`
-	TakenByMe     bool   `json:"taken_by_me"`
-	IsMaker       bool   `json:"is_maker"`
-	Side          string `json:"side"`
-	Type          string `json:"type"`
-	Rate          string `json:"rate"`
-	Fee           string `json:"fee"`
-	Credit        string `json:"credit"`
-	Amount        string `json:"amount"`
-	Ts            int    `json:"ts"`
+	TxnID         string          `json:"txn_id"`
+	OrderID       string          `json:"order_id"`
+	Hash          string          `json:"hash"`
+	ParentOrderID string          `json:"parent_order_id"`
+	SuperOrderID  string          `json:"super_order_id"`
+	TakenByMe     bool            `json:"taken_by_me"`
+	IsMaker       bool            `json:"is_maker"`
+	Side          string          `json:"side"`
+	Type          string          `json:"type"`
+	Rate          decimal.Decimal `json:"rate"`
+	Fee           decimal.Decimal `json:"fee"`
+	Credit        decimal.Decimal `json:"credit"`
+	Amount        decimal.Decimal `json:"amount"`
+	ClientID      string          `json:"client_id"`
+	Ts            Timestamp       `json:"ts"`
 }
 
 type BKPaginate struct {
@@ -147,32 +198,34 @@ type OrderInfoResult struct {
 	First         string                   `json:"first"`
 	Parent        string                   `json:"parent"`
 	Last          string                   `json:"last"`
-	Amount        string                   `json:"amount"`
-	Rate          int                      `json:"rate"`
-	Fee           int                      `json:"fee"`
-	Credit        int                      `json:"credit"`
-	Filled        float64                  `json:"filled"`
-	Total         int                      `json:"total"`
+	ClientID      string                   `json:"client_id"`
+	PostOnly      bool                     `json:"post_only"`
+	Amount        decimal.Decimal          `json:"amount"`
+	Rate          decimal.Decimal          `json:"rate"`
+	Fee           decimal.Decimal          `json:"fee"`
+	Credit        decimal.Decimal          `json:"credit"`
+	Filled        decimal.Decimal          `json:"filled"`
+	Total         decimal.Decimal          `json:"total"`
 	Status        string                   `json:"status"`
 	PartialFilled bool                     `json:"partial_filled"`
-	Remaining     int                      `json:"remaining"`
+	Remaining     decimal.Decimal          `json:"remaining"`
 	History       []OrderInfoResultHistory `json:"history"`
 }
 
 type OrderInfoResultHistory struct {
-	Amount    float64 `json:"amount"`
-	Credit    float64 `json:"credit"`
-	Fee       float64 `json:"fee"`
-	Hash      string  `json:"hash"`
-	ID        string  `json:"id"`
-	Rate      int     `json:"rate"`
-	Timestamp int64   `json:"timestamp"`
-	TxnID     string  `json:"txn_id"`
+	Amount    decimal.Decimal `json:"amount"`
+	Credit    decimal.Decimal `json:"credit"`
+	Fee       decimal.Decimal `json:"fee"`
+	Hash      string          `json:"hash"`
+	ID        string          `json:"id"`
+	Rate      decimal.Decimal `json:"rate"`
+	Timestamp Timestamp       `json:"timestamp"`
+	TxnID     string          `json:"txn_id"`
 }
 
 type TradingCredit struct {
-	Error  int     `json:"error"`
-	Result float64 `json:"result"`
+	Error  int             `json:"error"`
+	Result decimal.Decimal `json:"result"`
 }
 
 type Limits struct {
@@ -183,42 +236,40 @@ type Limits struct {
 type LimitsResult struct {
 	Limits struct {
 		Crypto struct {
-			Deposit  float64 `json:"deposit"`
-			Withdraw float64 `json:"withdraw"`
+			Deposit  decimal.Decimal `json:"deposit"`
+			Withdraw decimal.Decimal `json:"withdraw"`
 		} `json:"crypto"`
 		Fiat struct {
-			Deposit  float32 `json:"deposit"`
-			Withdraw float32 `json:"withdraw"`
+			Deposit  decimal.Decimal `json:"deposit"`
+			Withdraw decimal.Decimal `json:"withdraw"`
 		} `json:"fiat"`
 	} `json:"limits"`
 	Usage struct {
 		Crypto struct {
-			Deposit               float64 `json:"deposit"`
-			Withdraw              float64 `json:"withdraw"`
-			DepositPercentage     float64 `json:"deposit_percentage"`
-			WithdrawPercentage    float64 `json:"withdraw_percentage"`
-			DepositThbEquivalent  float64 `json:"deposit_thb_equivalent"`
-			WithdrawThbEquivalent float64 `json:"withdraw_thb_equivalent"`
+			Deposit               decimal.Decimal `json:"deposit"`
+			Withdraw              decimal.Decimal `json:"withdraw"`
+			DepositPercentage     float64         `json:"deposit_percentage"`
+			WithdrawPercentage    float64         `json:"withdraw_percentage"`
+			DepositThbEquivalent  decimal.Decimal `json:"deposit_thb_equivalent"`
+			WithdrawThbEquivalent decimal.Decimal `json:"withdraw_thb_equivalent"`
 		} `json:"crypto"`
 		Fiat struct {
-			Deposit            float32 `json:"deposit"`
-			Withdraw           float32 `json:"withdraw"`
-			DepositPercentage  float32 `json:"deposit_percentage"`
-			WithdrawPercentage float32 `json:"withdraw_percentage"`
+			Deposit            decimal.Decimal `json:"deposit"`
+			Withdraw           decimal.Decimal `json:"withdraw"`
+			DepositPercentage  float32         `json:"deposit_percentage"`
+			WithdrawPercentage float32         `json:"withdraw_percentage"`
 		} `json:"fiat"`
 	} `json:"usage"`
-	Rate float32 `json:"rate"`
+	Rate decimal.Decimal `json:"rate"`
 }
 
 type Wallet struct {
 	Error  int          `json:"error"`
 	Result WalletResult `json:"result"`
 }
-type WalletResult struct {
-	Thb float64 `json:"THB"`
-	Btc float64 `json:"BTC"`
-	Eth float64 `json:"ETH"`
-}
+
+// WalletResult is the available balance of every currency, keyed by currency (e.g. THB, BTC).
+type WalletResult map[string]decimal.Decimal
 
 type Balances struct {
 	Error  int           `json:"error"`
@@ -228,8 +279,8 @@ type Balances struct {
 type BalanceResult map[string]BalanceMapResult
 
 type BalanceMapResult struct {
-	Available float64 `json:"available"`
-	Reserved  float64 `json:"reserved"`
+	Available decimal.Decimal `json:"available"`
+	Reserved  decimal.Decimal `json:"reserved"`
 }
 
 type WsToken struct {
@@ -243,13 +294,13 @@ type InternalWithdraw struct {
 }
 
 type InternalWithdrawResult struct {
-	Txn string  `json:"txn"`
-	Adr string  `json:"adr"`
-	Mem string  `json:"mem"`
-	Cur string  `json:"cur"`
-	Amt float64 `json:"amt"`
-	Fee float64 `json:"fee"`
-	Ts  int     `json:"ts"`
+	Txn string          `json:"txn"`
+	Adr string          `json:"adr"`
+	Mem string          `json:"mem"`
+	Cur string          `json:"cur"`
+	Amt decimal.Decimal `json:"amt"`
+	Fee decimal.Decimal `json:"fee"`
+	Ts  Timestamp       `json:"ts"`
 }
 
 type DepositHistory struct {
@@ -259,14 +310,14 @@ type DepositHistory struct {
 }
 
 type DepositHistoryResult struct {
-	Hash          string  `json:"hash"`
-	Currency      string  `json:"currency"`
-	Amount        float64 `json:"amount"`
-	FromAddress   string  `json:"from_address"`
-	ToAddress     string  `json:"to_address"`
-	Confirmations int     `json:"confirmations"`
-	Status        string  `json:"status"`
-	Time          int     `json:"time"`
+	Hash          string          `json:"hash"`
+	Currency      string          `json:"currency"`
+	Amount        decimal.Decimal `json:"amount"`
+	FromAddress   string          `json:"from_address"`
+	ToAddress     string          `json:"to_address"`
+	Confirmations int             `json:"confirmations"`
+	Status        string          `json:"status"`
+	Time          Timestamp       `json:"time"`
 }
 
 type WithdrawHistory struct {
@@ -276,14 +327,14 @@ type WithdrawHistory struct {
 }
 
 type WithdrawHistoryResult struct {
-	TxnID    string  `json:"txn_id"`
-	Hash     string  `json:"hash"`
-	Currency string  `json:"currency"`
-	Amount   string  `json:"amount"`
-	Fee      float64 `json:"fee"`
-	Address  string  `json:"address"`
-	Status   string  `json:"status"`
-	Time     int     `json:"time"`
+	TxnID    string          `json:"txn_id"`
+	Hash     string          `json:"hash"`
+	Currency string          `json:"currency"`
+	Amount   decimal.Decimal `json:"amount"`
+	Fee      decimal.Decimal `json:"fee"`
+	Address  string          `json:"address"`
+	Status   string          `json:"status"`
+	Time     Timestamp       `json:"time"`
 }
 
 type PlaceBid struct {
@@ -292,16 +343,16 @@ type PlaceBid struct {
 }
 
 type PlaceBidResult struct {
-	ID   string  `json:"id"`
-	Hash string  `json:"hash"`
-	Typ  string  `json:"typ"`
-	Amt  int     `json:"amt"`
-	Rat  int     `json:"rat"`
-	Fee  float64 `json:"fee"`
-	Cre  float64 `json:"cre"`
-	Rec  float64 `json:"rec"`
-	Ts   int     `json:"ts"`
-	Ci   string  `json:"ci"`
+	ID   string          `json:"id"`
+	Hash string          `json:"hash"`
+	Typ  string          `json:"typ"`
+	Amt  decimal.Decimal `json:"amt"`
+	Rat  decimal.Decimal `json:"rat"`
+	Fee  decimal.Decimal `json:"fee"`
+	Cre  decimal.Decimal `json:"cre"`
+	Rec  decimal.Decimal `json:"rec"`
+	Ts   Timestamp       `json:"ts"`
+	Ci   string          `json:"ci"`
 }
 
 type PlaceAsk struct {
@@ -310,16 +361,66 @@ type PlaceAsk struct {
 }
 
 type PlaceAskResult struct {
-	ID   string  `json:"id"`
-	Hash string  `json:"hash"`
-	Typ  string  `json:"typ"`
-	Amt  float64 `json:"amt"`
-	Rat  int     `json:"rat"`
-	Fee  float64 `json:"fee"`
-	Cre  float64 `json:"cre"`
-	Rec  int     `json:"rec"`
-	Ts   int     `json:"ts"`
-	Ci   string  `json:"ci"`
+	ID   string          `json:"id"`
+	Hash string          `json:"hash"`
+	Typ  string          `json:"typ"`
+	Amt  decimal.Decimal `json:"amt"`
+	Rat  decimal.Decimal `json:"rat"`
+	Fee  decimal.Decimal `json:"fee"`
+	Cre  decimal.Decimal `json:"cre"`
+	Rec  decimal.Decimal `json:"rec"`
+	Ts   Timestamp       `json:"ts"`
+	Ci   string          `json:"ci"`
+}
+
+// PlaceOrderResult is the result of Place, the result of PlaceBid or PlaceAsk according to the side of the order.
+type PlaceOrderResult struct {
+	ID   string          `json:"id"`
+	Hash string          `json:"hash"`
+	Typ  string          `json:"typ"`
+	Amt  decimal.Decimal `json:"amt"`
+	Rat  decimal.Decimal `json:"rat"`
+	Fee  decimal.Decimal `json:"fee"`
+	Cre  decimal.Decimal `json:"cre"`
+	Rec  decimal.Decimal `json:"rec"`
+	Ts   Timestamp       `json:"ts"`
+	Ci   string          `json:"ci"`
+}
+
+// /api/market/place-bid/test and /api/market/place-ask/test
+type PlaceTest struct {
+	Error  int             `json:"error"`
+	Result PlaceTestResult `json:"result"`
+}
+
+type PlaceTestResult struct {
+	ID   StringOrNumber  `json:"id"`
+	Hash string          `json:"hash"`
+	Typ  string          `json:"typ"`
+	Amt  decimal.Decimal `json:"amt"`
+	Rat  decimal.Decimal `json:"rat"`
+	Fee  decimal.Decimal `json:"fee"`
+	Cre  decimal.Decimal `json:"cre"`
+	Rec  decimal.Decimal `json:"rec"`
+	Ts   Timestamp       `json:"ts"`
+}
+
+// /api/market/place-ask-by-fiat
+type PlaceAskByFiat struct {
+	Error  int                  `json:"error"`
+	Result PlaceAskByFiatResult `json:"result"`
+}
+
+type PlaceAskByFiatResult struct {
+	ID   StringOrNumber  `json:"id"`
+	Hash string          `json:"hash"`
+	Typ  string          `json:"typ"`
+	Amt  decimal.Decimal `json:"amt"` // Amount of the ask in the base currency (e.g. BTC)
+	Rat  decimal.Decimal `json:"rat"`
+	Fee  decimal.Decimal `json:"fee"`
+	Cre  decimal.Decimal `json:"cre"`
+	Rec  decimal.Decimal `json:"rec"` // Amount received in THB
+	Ts   Timestamp       `json:"ts"`
 }
 
 type CancelOrder struct {
@@ -333,10 +434,10 @@ type CryptoAddresses struct {
 }
 
 type CryptoAddressesResult struct {
-	Currency string `json:"currency"`
-	Address  string `json:"address"`
-	Tag      int    `json:"tag"`
-	Time     int    `json:"time"`
+	Currency string         `json:"currency"`
+	Address  string         `json:"address"`
+	Tag      StringOrNumber `json:"tag"`
+	Time     Timestamp      `json:"time"`
 }
 
 type CryptoGenerateAddress struct {
@@ -356,13 +457,13 @@ type CryptoWithdraw struct {
 }
 
 type CryptoWithdrawResult struct {
-	Txn string  `json:"txn"`
-	Adr string  `json:"adr"`
-	Mem string  `json:"mem"`
-	Cur string  `json:"cur"`
-	Amt float64 `json:"amt"`
-	Fee float64 `json:"fee"`
-	Ts  int     `json:"ts"`
+	Txn string          `json:"txn"`
+	Adr string          `json:"adr"`
+	Mem string          `json:"mem"`
+	Cur string          `json:"cur"`
+	Amt decimal.Decimal `json:"amt"`
+	Fee decimal.Decimal `json:"fee"`
+	Ts  Timestamp       `json:"ts"`
 }
 
 type FiatAccounts struct {
@@ -372,10 +473,10 @@ type FiatAccounts struct {
 }
 
 type FiatAccountsResult struct {
-	ID   string `json:"id"`
-	Bank string `json:"bank"`
-	Name string `json:"name"`
-	Time int    `json:"time"`
+	ID   string    `json:"id"`
+	Bank string    `json:"bank"`
+	Name string    `json:"name"`
+	Time Timestamp `json:"time"`
 }
 
 type FiatWithdraw struct {
@@ -384,13 +485,13 @@ type FiatWithdraw struct {
 }
 
 type FiatWithdrawResult struct {
-	Txn string `json:"txn"`
-	Acc string `json:"acc"`
-	Cur string `json:"cur"`
-	Amt int    `json:"amt"`
-	Fee int    `json:"fee"`
-	Rec int    `json:"rec"`
-	Ts  int    `json:"ts"`
+	Txn string          `json:"txn"`
+	Acc string          `json:"acc"`
+	Cur string          `json:"cur"`
+	Amt decimal.Decimal `json:"amt"`
+	Fee decimal.Decimal `json:"fee"`
+	Rec decimal.Decimal `json:"rec"`
+	Ts  Timestamp       `json:"ts"`
 }
 
 type FiatDepositHistory struct {
@@ -400,11 +501,11 @@ type FiatDepositHistory struct {
 }
 
 type FiatDepositHistoryResult struct {
-	TxnID    string  `json:"txn_id"`
-	Currency string  `json:"currency"`
-	Amount   float64 `json:"amount"`
-	Status   string  `json:"status"`
-	Time     int     `json:"time"`
+	TxnID    string          `json:"txn_id"`
+	Currency string          `json:"currency"`
+	Amount   decimal.Decimal `json:"amount"`
+	Status   string          `json:"status"`
+	Time     Timestamp       `json:"time"`
 }
 
 type FiatWithdrawHistory struct {
@@ -414,41 +515,10 @@ type FiatWithdrawHistory struct {
 }
 
 type FiatWithdrawHistoryResult struct {
-	TxnID    string `json:"txn_id"`
-	Currency string `json:"currency"`
-	Amount   string `json:"amount"`
-	Fee      int    `json:"fee"`
-	Status   string `json:"status"`
-	Time     int    `json:"time"`
-}
-
-// websocket response
-type WsTrade struct {
-	Amt    float64 `json:"amt"`
-	Bid    string  `json:"bid"`
-	Rat    float64 `json:"rat"`
-	Sid    string  `json:"sid"`
-	Stream string  `json:"stream"`
-	Sym    string  `json:"sym"`
-	Ts     int     `json:"ts"`
-	Txn    string  `json:"txn"`
-}
-
-type WsTicker struct {
-	Stream         string  `json:"stream"`
-	ID             int     `json:"id"`
-	Last           float64 `json:"last"`
-	LowestAsk      float64 `json:"lowestAsk"`
-	LowestAskSize  float64 `json:"lowestAskSize"`
-	HighestBid     float64 `json:"highestBid"`
-	HighestBidSize float64 `json:"highestBidSize"`
-	Change         float64 `json:"change"`
-	PercentChange  float64 `json:"percentChange"`
-	BaseVolume     float64 `json:"baseVolume"`
-	QuoteVolume    float64 `json:"quoteVolume"`
-	IsFrozen       int     `json:"isFrozen"`
-	High24Hr       float64 `json:"high24hr"`
-	Low24Hr        float64 `json:"low24hr"`
-	Open           float64 `json:"open"`
-	Close          float64 `json:"close"`
+	TxnID    string          `json:"txn_id"`
+	Currency string          `json:"currency"`
+	Amount   decimal.Decimal `json:"amount"`
+	Fee      decimal.Decimal `json:"fee"`
+	Status   string          `json:"status"`
+	Time     Timestamp       `json:"time"`
 }
@@ -0,0 +1,73 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a unix time sent by Bitkub either as a JSON number or as a JSON string,
+// in seconds or in milliseconds depending on the endpoint.
+type Timestamp int64
+
+// UnmarshalJSON decodes t from a JSON number or a JSON string.
+// null and the empty string decode as 0.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(bytes.TrimSpace(data), `"`))
+	if s == "" || s == "null" {
+		*t = 0
+		return nil
+	}
+
+	// Some endpoints send the timestamp as a float (e.g. 1702543272.0)
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		f, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil {
+			return fmt.Errorf("invalid timestamp %s", data)
+		}
+		i = int64(f)
+	}
+	*t = Timestamp(i)
+	return nil
+}
+
+// Time returns t as a time.Time, a value above 1e12 is read as milliseconds and any other value as seconds.
+func (t Timestamp) Time() time.Time {
+	if t > 1e12 {
+		return time.UnixMilli(int64(t))
+	}
+	return time.Unix(int64(t), 0)
+}
+
+// StringOrNumber is a string field sent by Bitkub either as a JSON string or as a JSON number
+// (e.g. parent_id is 0 on some endpoints and "0" on others).
+type StringOrNumber string
+
+// UnmarshalJSON decodes s from a JSON string or the literal of a JSON number.
+// null decodes as the empty string.
+func (s *StringOrNumber) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*s = ""
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		*s = StringOrNumber(str)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*s = StringOrNumber(n)
+	return nil
+}
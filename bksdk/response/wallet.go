@@ -0,0 +1,45 @@
+package response
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+)
+
+// Get returns the balance of a currency, the currency is matched case-insensitively (e.g. btc or BTC).
+// The boolean is false when the wallet does not list the currency.
+func (w WalletResult) Get(currency string) (decimal.Decimal, bool) {
+	// Try the exact key first, Bitkub sends upper case currencies
+	if balance, ok := w[strings.ToUpper(currency)]; ok {
+		return balance, true
+	}
+
+	for cur, balance := range w {
+		if strings.EqualFold(cur, currency) {
+			return balance, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// NonZero returns a copy of the wallet without the currencies with a zero balance.
+func (w WalletResult) NonZero() WalletResult {
+	nonZero := make(WalletResult)
+	for cur, balance := range w {
+		if !balance.IsZero() {
+			nonZero[cur] = balance
+		}
+	}
+	return nonZero
+}
+
+// Currencies returns the currencies of the wallet sorted alphabetically.
+func (w WalletResult) Currencies() []string {
+	currencies := make([]string, 0, len(w))
+	for cur := range w {
+		currencies = append(currencies, cur)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
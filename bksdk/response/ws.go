@@ -0,0 +1,182 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+)
+
+// WsTrade is a message of the market.trade.<symbol> stream.
+type WsTrade struct {
+	Symbol string          `json:"-"` // Symbol of the stream in lower case (e.g. thb_btc)
+	Amt    decimal.Decimal `json:"amt"`
+	Bid    string          `json:"bid"`
+	Rat    decimal.Decimal `json:"rat"`
+	Sid    string          `json:"sid"`
+	Stream string          `json:"stream"`
+	Sym    string          `json:"sym"`
+	Ts     int             `json:"ts"`
+	Txn    string          `json:"txn"`
+}
+
+// WsTicker is a message of the market.ticker.<symbol> stream.
+type WsTicker struct {
+	Symbol         string          `json:"-"` // Symbol of the stream in lower case (e.g. thb_btc)
+	Stream         string          `json:"stream"`
+	ID             int             `json:"id"`
+	Last           decimal.Decimal `json:"last"`
+	LowestAsk      decimal.Decimal `json:"lowestAsk"`
+	LowestAskSize  decimal.Decimal `json:"lowestAskSize"`
+	HighestBid     decimal.Decimal `json:"highestBid"`
+	HighestBidSize decimal.Decimal `json:"highestBidSize"`
+	Change         decimal.Decimal `json:"change"`
+	PercentChange  decimal.Decimal `json:"percentChange"`
+	BaseVolume     decimal.Decimal `json:"baseVolume"`
+	QuoteVolume    decimal.Decimal `json:"quoteVolume"`
+	IsFrozen       int             `json:"isFrozen"`
+	High24Hr       decimal.Decimal `json:"high24hr"`
+	Low24Hr        decimal.Decimal `json:"low24hr"`
+	Open           decimal.Decimal `json:"open"`
+	Close          decimal.Decimal `json:"close"`
+}
+
+// UnmarshalJSON decodes t and sets its Symbol from the stream name.
+func (t *WsTrade) UnmarshalJSON(data []byte) error {
+	type wsTrade WsTrade
+	if err := json.Unmarshal(data, (*wsTrade)(t)); err != nil {
+		return err
+	}
+	_, t.Symbol = ParseStream(t.Stream)
+	return nil
+}
+
+// UnmarshalJSON decodes t and sets its Symbol from the stream name.
+func (t *WsTicker) UnmarshalJSON(data []byte) error {
+	type wsTicker WsTicker
+	if err := json.Unmarshal(data, (*wsTicker)(t)); err != nil {
+		return err
+	}
+	_, t.Symbol = ParseStream(t.Stream)
+	return nil
+}
+
+// ParseStream splits a stream name into its kind and its symbol,
+// e.g. market.ticker.thb_btc gives market.ticker and thb_btc.
+func ParseStream(stream string) (kind, symbol string) {
+	i := strings.LastIndexByte(stream, '.')
+	if i < 0 {
+		return stream, ""
+	}
+	return stream[:i], strings.ToLower(stream[i+1:])
+}
+
+// WsOrderUpdate is a message of the private.order stream: a change of one of our orders.
+type WsOrderUpdate struct {
+	Stream    string          `json:"stream"`
+	ID        StringOrNumber  `json:"id"`
+	Hash      string          `json:"hash"`
+	Sym       string          `json:"sym"`
+	Side      string          `json:"side"`
+	Type      string          `json:"type"`
+	Rate      decimal.Decimal `json:"rate"`
+	Amount    decimal.Decimal `json:"amount"`
+	Filled    decimal.Decimal `json:"filled"`
+	Remaining decimal.Decimal `json:"remaining"`
+	Status    string          `json:"status"`
+	ClientID  string          `json:"client_id"`
+	Ts        Timestamp       `json:"ts"`
+}
+
+// WsMatch is a message of the private.match stream: a fill of one of our orders.
+type WsMatch struct {
+	Stream  string          `json:"stream"`
+	TxnID   string          `json:"txn_id"`
+	OrderID StringOrNumber  `json:"order_id"`
+	Hash    string          `json:"hash"`
+	Sym     string          `json:"sym"`
+	Side    string          `json:"side"`
+	Rate    decimal.Decimal `json:"rate"`
+	Amount  decimal.Decimal `json:"amount"`
+	Fee     decimal.Decimal `json:"fee"`
+	Credit  decimal.Decimal `json:"credit"`
+	IsMaker bool            `json:"is_maker"`
+	Ts      Timestamp       `json:"ts"`
+}
+
+// WsBookLevel is a price level of the order book stream.
+// Bitkub sends it as the tuple [volume, rate, amount, reserved, is new, user owner].
+type WsBookLevel struct {
+	Volume decimal.Decimal // Value of the level in the quote currency (e.g. THB)
+	Rate   decimal.Decimal // Rate of the level
+	Amount decimal.Decimal // Amount of the level in the base currency (e.g. BTC)
+}
+
+// UnmarshalJSON decodes l from its tuple form.
+func (l *WsBookLevel) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) < 3 {
+		return fmt.Errorf("book level: expected at least 3 fields, got %d", len(fields))
+	}
+
+	if err := json.Unmarshal(fields[0], &l.Volume); err != nil {
+		return fmt.Errorf("book level volume: %w", err)
+	}
+	if err := json.Unmarshal(fields[1], &l.Rate); err != nil {
+		return fmt.Errorf("book level rate: %w", err)
+	}
+	if err := json.Unmarshal(fields[2], &l.Amount); err != nil {
+		return fmt.Errorf("book level amount: %w", err)
+	}
+	return nil
+}
+
+// Events of the order book stream.
+const (
+	WsBidsChanged   = "bidschanged"   // Data holds the bids
+	WsAsksChanged   = "askschanged"   // Data holds the asks
+	WsTradesChanged = "tradeschanged" // Data holds the trades, the bids and the asks
+)
+
+// WsOrderBook is a message of the orderbook/<symbol id> stream.
+// Every change event carries the whole top of the changed side, not a delta.
+type WsOrderBook struct {
+	Event     string          `json:"event"`
+	PairingID int             `json:"pairing_id"`
+	Data      json.RawMessage `json:"data"`
+	Bids      []WsBookLevel   `json:"-"` // Set by the bidschanged and tradeschanged events
+	Asks      []WsBookLevel   `json:"-"` // Set by the askschanged and tradeschanged events
+}
+
+// UnmarshalJSON decodes b and the levels of its change event.
+// Other events (e.g. ticker) only decode the raw Data.
+func (b *WsOrderBook) UnmarshalJSON(data []byte) error {
+	type wsOrderBook WsOrderBook
+	if err := json.Unmarshal(data, (*wsOrderBook)(b)); err != nil {
+		return err
+	}
+
+	switch b.Event {
+	case WsBidsChanged:
+		return json.Unmarshal(b.Data, &b.Bids)
+	case WsAsksChanged:
+		return json.Unmarshal(b.Data, &b.Asks)
+	case WsTradesChanged:
+		var parts []json.RawMessage
+		if err := json.Unmarshal(b.Data, &parts); err != nil {
+			return err
+		}
+		if len(parts) < 3 {
+			return fmt.Errorf("tradeschanged: expected 3 parts, got %d", len(parts))
+		}
+		if err := json.Unmarshal(parts[1], &b.Bids); err != nil {
+			return err
+		}
+		return json.Unmarshal(parts[2], &b.Asks)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package bksdk
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+)
+
+// RetryPolicy configures the automatic retry of failed calls.
+// It is applied to every read endpoint (GET endpoints and the POST endpoints that only read data).
+// Order placement is only retried when enabled with WithOrderRetry.
+type RetryPolicy struct {
+	MaxAttempts int              // Total number of attempts including the first one, 1 disables retrying
+	BaseDelay   time.Duration    // Delay before the first retry, doubled on every following retry
+	MaxDelay    time.Duration    // Upper bound of the delay between two attempts
+	Jitter      float64          // Fraction of the delay that is randomised, between 0 and 1
+	Retryable   func(error) bool // Decides whether an error is retried, bkerr.IsRetryable when nil
+}
+
+// DefaultRetryPolicy retries network errors, 5xx, 429 and the Bitkub server error code
+// up to 3 attempts with an exponential backoff starting at 200ms.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.2,
+}
+
+// NoRetry disables the automatic retry.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// retryable reports whether err should be retried by the policy.
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return bkerr.IsRetryable(err)
+}
+
+// delay returns the backoff before the given retry (1 for the first retry).
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	// Spread the delay by +/- Jitter so concurrent callers do not retry in lockstep
+	if p.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// wait sleeps for the backoff of the given retry, or returns early with the context error.
+func (p RetryPolicy) wait(ctx context.Context, retry int) error {
+	timer := time.NewTimer(p.delay(retry))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy applied to read endpoints (and to order placement with WithOrderRetry).
+// Use NoRetry to disable retrying.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(bksdk *SDK) error {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		bksdk.retryPolicy = policy
+		return nil
+	}
+}
+
+// orderHistoryLookup is the number of the latest matched orders searched by client_id before an order is retried.
+const orderHistoryLookup = 100
+
+// WithOrderRetry enables the retry of PlaceBid and PlaceAsk.
+// An order is only retried when it has a client_id: before every retry the open orders and the latest
+// matched orders of the order history are looked up by client_id, and if the order was created by a
+// previous attempt it is returned instead of being placed again. This covers the orders still in the book
+// as well as the orders filled, or partly filled and then removed from the book, by the time of the retry.
+func WithOrderRetry() Option {
+	return func(bksdk *SDK) error {
+		bksdk.orderRetry = true
+		return nil
+	}
+}
+
+// retryOrder makes an order placement call retryable when WithOrderRetry is enabled and the order has a client_id.
+// Before every retry the open orders of sym, then its latest matched orders, are looked up by side and client_id,
+// onFound receives the order created by a previous attempt and the retry stops there.
+func (bksdk *SDK) retryOrder(c *call, sym, side, clientID string, onFound func(response.MyOpenOrderResult)) {
+	if !bksdk.orderRetry || clientID == "" {
+		return
+	}
+
+	c.idempotent = true
+	c.beforeRetry = func(ctx context.Context) (bool, error) {
+		// The order is still in the book
+		orders, err := bksdk.MyOpenOrderCtx(ctx, sym)
+		if err != nil {
+			return false, err
+		}
+		for _, order := range orders {
+			if order.ClientID == clientID && order.Side == side {
+				onFound(order)
+				return true, nil
+			}
+		}
+
+		// The order was filled, or partly filled and then removed from the book
+		history, _, err := bksdk.MyOrderHistoryCtx(ctx, sym, 0, orderHistoryLookup, 0, 0)
+		if err != nil {
+			return false, err
+		}
+		for _, match := range history {
+			if match.ClientID == clientID && match.Side == side {
+				onFound(response.MyOpenOrderResult{
+					ID:       match.OrderID,
+					Hash:     match.Hash,
+					Side:     match.Side,
+					Type:     match.Type,
+					Rate:     match.Rate,
+					Fee:      match.Fee,
+					Credit:   match.Credit,
+					Amount:   match.Amount,
+					ClientID: match.ClientID,
+					Ts:       match.Ts,
+				})
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
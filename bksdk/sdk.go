@@ -1,79 +1,190 @@
 package bksdk
 
 import (
+	"context"
+	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
 	"github.com/naruebaet/bitkub-sdk/bksdk/response"
-	"github.com/parnurzeal/gorequest"
 )
 
+// SDK is the Bitkub API client.
+// It is safe for concurrent use by multiple goroutines: its configuration is only written
+// while it is created, every call builds its own request, and the shared parts
+// (http transport, rate limiters, clock) are synchronised.
 type SDK struct {
-	apiHost   *url.URL
-	req       *gorequest.SuperAgent
-	apiKey    string
-	apiSecret string
+	apiHost     *url.URL
+	wsHost      string
+	httpClient  *http.Client
+	timeout     time.Duration
+	userAgent   string
+	retryPolicy RetryPolicy
+	orderRetry  bool
+	dryRun      bool
+	precision   PrecisionPolicy
+	symbols     *symbolRegistry
+	rateLimits  map[api.Group]RateLimit
+	limiters    map[api.Group]*tokenBucket
+	clock       *clock
+	syncEvery   time.Duration
+	apiKey      string
+	apiSecret   string
 }
 
+// SDKEndpoints lists every endpoint of the SDK.
+// Each endpoint has a Ctx variant taking a context.Context as its first parameter,
+// the variant without context uses context.Background().
 type SDKEndpoints interface {
 	// public endpoints
 	GetStatus() (response.Status, error)
+	GetStatusCtx(ctx context.Context) (response.Status, error)
 	GetServerTime() (string, error)
+	GetServerTimeCtx(ctx context.Context) (string, error)
 	GetSymbols() ([]response.MarketSymbolsResult, error)
+	GetSymbolsCtx(ctx context.Context) ([]response.MarketSymbolsResult, error)
+	GetMarketSymbols() ([]response.SymbolInfo, error)
+	GetMarketSymbolsCtx(ctx context.Context) ([]response.SymbolInfo, error)
 	GetTicker(sym string) (map[string]response.MarketTickerData, error)
+	GetTickerCtx(ctx context.Context, sym string) (map[string]response.MarketTickerData, error)
 	GetTrade(sym string, limit int) (response.MarketTradesResult, error)
+	GetTradeCtx(ctx context.Context, sym string, limit int) (response.MarketTradesResult, error)
 	GetBids(sym string, limit int) (response.MarketResult, error)
+	GetBidsCtx(ctx context.Context, sym string, limit int) (response.MarketResult, error)
 	GetAsks(sym string, limit int) (response.MarketResult, error)
+	GetAsksCtx(ctx context.Context, sym string, limit int) (response.MarketResult, error)
 	GetBooks(sym string, limit int) (response.MarketBooksResult, error)
+	GetBooksCtx(ctx context.Context, sym string, limit int) (response.MarketBooksResult, error)
 	GetDepth(sym string, limit int) (response.MarketDepth, error)
-	GetHistory(symbol string, resolution string, from int, to int) (response.TradingviewHistory, error)
+	GetDepthCtx(ctx context.Context, sym string, limit int) (response.MarketDepth, error)
+	GetHistory(symbol string, resolution string, from, to time.Time) ([]response.Candle, error)
+	GetHistoryCtx(ctx context.Context, symbol string, resolution string, from, to time.Time) ([]response.Candle, error)
 
 	// User secure endpoints
-	TradingCredit() (float64, error)
+	TradingCredit() (decimal.Decimal, error)
+	TradingCreditCtx(ctx context.Context) (decimal.Decimal, error)
 	Limits() (response.LimitsResult, error)
+	LimitsCtx(ctx context.Context) (response.LimitsResult, error)
 
 	// Market secure endpoints
 	Wallet() (response.WalletResult, error)
+	WalletCtx(ctx context.Context) (response.WalletResult, error)
 	Balances() (response.BalanceResult, error)
-	PlaceBid(sym string, amt, rat float64, typ, client_id string) (response.PlaceBidResult, error)
-	PlaceAsk(sym string, amt, rat float64, typ, client_id string) (response.PlaceAskResult, error)
+	BalancesCtx(ctx context.Context) (response.BalanceResult, error)
+	PlaceBid(sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceBidResult, error)
+	PlaceBidCtx(ctx context.Context, sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceBidResult, error)
+	PlaceAsk(sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceAskResult, error)
+	PlaceAskCtx(ctx context.Context, sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceAskResult, error)
+	Place(ctx context.Context, order *Order) (response.PlaceOrderResult, error)
+	SymbolInfo(sym string) (response.SymbolInfo, error)
+	SymbolInfoCtx(ctx context.Context, sym string) (response.SymbolInfo, error)
+	PlaceAskByFiat(sym string, amt, rat decimal.Decimal, typ string) (response.PlaceAskByFiatResult, error)
+	PlaceAskByFiatCtx(ctx context.Context, sym string, amt, rat decimal.Decimal, typ string) (response.PlaceAskByFiatResult, error)
 	CancelOrder(sym, id, sd, hash string) (response.CancelOrder, error)
+	CancelOrderCtx(ctx context.Context, sym, id, sd, hash string) (response.CancelOrder, error)
 	WsToken() (token string, err error)
+	WsTokenCtx(ctx context.Context) (token string, err error)
 	MyOpenOrder(sym string) ([]response.MyOpenOrderResult, error)
+	MyOpenOrderCtx(ctx context.Context, sym string) ([]response.MyOpenOrderResult, error)
 	MyOrderHistory(sym string, page, limit, start, end int) ([]response.MyOrderHistoryResult, response.BKPaginate, error)
+	MyOrderHistoryCtx(ctx context.Context, sym string, page, limit, start, end int) ([]response.MyOrderHistoryResult, response.BKPaginate, error)
 	OrderInfo(sym, orderId, side string) (response.OrderInfoResult, error)
+	OrderInfoCtx(ctx context.Context, sym, orderId, side string) (response.OrderInfoResult, error)
 	OrderInfoByHash(hash string) (response.OrderInfoResult, error)
+	OrderInfoByHashCtx(ctx context.Context, hash string) (response.OrderInfoResult, error)
 
 	// Crypto secure endpoints
-	CryptoInternalWithdraw(currency string, address string, memo string, amount float64) (response.InternalWithdrawResult, error)
+	CryptoInternalWithdraw(currency string, address string, memo string, amount decimal.Decimal) (response.InternalWithdrawResult, error)
+	CryptoInternalWithdrawCtx(ctx context.Context, currency string, address string, memo string, amount decimal.Decimal) (response.InternalWithdrawResult, error)
 	CryptoAddresses(page, limit int) ([]response.CryptoAddressesResult, response.BKPaginate, error)
-	CryptoWithdraw(currency string, address string, memo string, amount float64, network string) (response.CryptoWithdrawResult, error)
+	CryptoAddressesCtx(ctx context.Context, page, limit int) ([]response.CryptoAddressesResult, response.BKPaginate, error)
+	CryptoWithdraw(currency string, address string, memo string, amount decimal.Decimal, network string) (response.CryptoWithdrawResult, error)
+	CryptoWithdrawCtx(ctx context.Context, currency string, address string, memo string, amount decimal.Decimal, network string) (response.CryptoWithdrawResult, error)
 	CryptoDepositHistory(page, limit int) ([]response.DepositHistoryResult, response.BKPaginate, error)
+	CryptoDepositHistoryCtx(ctx context.Context, page, limit int) ([]response.DepositHistoryResult, response.BKPaginate, error)
 	CryptoWithdrawHistory(page, limit int) ([]response.WithdrawHistoryResult, response.BKPaginate, error)
+	CryptoWithdrawHistoryCtx(ctx context.Context, page, limit int) ([]response.WithdrawHistoryResult, response.BKPaginate, error)
 	CryptoGenerateAddress(symbol string) ([]response.CryptoGenerateAddressResult, error)
+	CryptoGenerateAddressCtx(ctx context.Context, symbol string) ([]response.CryptoGenerateAddressResult, error)
 
 	// Fiat secure endpoints
 	FiatAccounts(page int, limit int) ([]response.FiatAccountsResult, response.BKPaginate, error)
-	FiatWithdraw(id string, amt float64) (response.FiatWithdrawResult, error)
+	FiatAccountsCtx(ctx context.Context, page int, limit int) ([]response.FiatAccountsResult, response.BKPaginate, error)
+	FiatWithdraw(id string, amt decimal.Decimal) (response.FiatWithdrawResult, error)
+	FiatWithdrawCtx(ctx context.Context, id string, amt decimal.Decimal) (response.FiatWithdrawResult, error)
 	FiatDepositHistory(page, limit int) ([]response.FiatDepositHistoryResult, response.BKPaginate, error)
+	FiatDepositHistoryCtx(ctx context.Context, page, limit int) ([]response.FiatDepositHistoryResult, response.BKPaginate, error)
 	FiatWithdrawHistory(page, limit int) ([]response.FiatWithdrawHistoryResult, response.BKPaginate, error)
+	FiatWithdrawHistoryCtx(ctx context.Context, page, limit int) ([]response.FiatWithdrawHistoryResult, response.BKPaginate, error)
+
+	// Websocket
+	CreateWsConnection(streamName string, reader chan string, ctx context.Context)
+	NewWsClient(ctx context.Context, opts ...WsOption) *WsClient
+	TickerStreams(ctx context.Context, symbols ...string) ([]string, error)
+	TradeStreams(ctx context.Context, symbols ...string) ([]string, error)
+	OrderBookStreams(ctx context.Context, symbols ...string) ([]string, error)
+
+	// Client-side rate limiter metrics
+	RateLimitStats() map[api.Group]RateLimitStats
 }
 
 // New creates a new SDK instance with the provided apiKey and apiSecret.
-// It initializes the gorequest super agent and sets the API host URL.
+// It uses the default Bitkub API and websocket hosts.
 func New(apiKey, apiSecret string) SDKEndpoints {
-	// Initialize the gorequest super agent
-	req := gorequest.New()
+	// New does not pass any option, so it can not fail
+	sdk, _ := NewWithOptions(apiKey, apiSecret)
+
+	return sdk
+}
 
-	// Set the API host URL
-	apiHostURL, _ := url.Parse("https://api.bitkub.com")
+// NewWithOptions creates a new SDK instance with the provided apiKey and apiSecret,
+// then applies the options in order.
+// It returns an error if any option is invalid (e.g. an unparsable base URL).
+func NewWithOptions(apiKey, apiSecret string, opts ...Option) (SDKEndpoints, error) {
+	// Set the default API host URL
+	apiHostURL, _ := url.Parse(DefaultAPIHost)
 
-	// Create a new SDK instance
+	// Create a new SDK instance with the defaults
 	sdk := &SDK{
-		apiHost:   apiHostURL,
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		req:       req,
+		apiHost:     apiHostURL,
+		wsHost:      DefaultWSHost,
+		retryPolicy: DefaultRetryPolicy,
+		syncEvery:   DefaultClockSyncInterval,
+		rateLimits:  make(map[api.Group]RateLimit, len(DefaultRateLimits)),
+		apiKey:      apiKey,
+		apiSecret:   apiSecret,
 	}
+	sdk.symbols = newSymbolRegistry(sdk)
 
-	return sdk
+	// Copy the default rate limits, so the options do not change the package defaults
+	for group, limit := range DefaultRateLimits {
+		sdk.rateLimits[group] = limit
+	}
+
+	// Apply the options
+	for _, opt := range opts {
+		if err := opt(sdk); err != nil {
+			return nil, err
+		}
+	}
+
+	// Use a copy of the client, so the timeout does not leak into the caller's client
+	client := http.Client{Transport: newTransport()}
+	if sdk.httpClient != nil {
+		client = *sdk.httpClient
+	}
+	if sdk.timeout > 0 {
+		client.Timeout = sdk.timeout
+	}
+	sdk.httpClient = &client
+
+	// Create the rate limiters
+	sdk.limiters = newLimiters(sdk.rateLimits)
+
+	// Create the clock signing the secure requests
+	sdk.clock = newClock(sdk.syncEvery, sdk.GetServerTimeCtx)
+
+	return sdk, nil
 }
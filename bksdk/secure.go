@@ -1,14 +1,15 @@
 package bksdk
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/naruebaet/bitkub-sdk/bksdk/api"
-	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
 	"github.com/naruebaet/bitkub-sdk/bksdk/request"
 	"github.com/naruebaet/bitkub-sdk/bksdk/response"
 )
@@ -22,33 +23,25 @@ import (
 // - response.MyOpenOrder: The response body containing the list of open orders
 // - error: Any error that occurred during the API call
 func (bksdk *SDK) MyOpenOrder(sym string) ([]response.MyOpenOrderResult, error) {
+	return bksdk.MyOpenOrderCtx(context.Background(), sym)
+}
+
+// MyOpenOrderCtx is like MyOpenOrder but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) MyOpenOrderCtx(ctx context.Context, sym string) ([]response.MyOpenOrderResult, error) {
 	// Initialize an empty variable to store the response body
 	var respBody response.MyOpenOrder
 
-	// Join the target URL with the host and path
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketMyOpenOrderV3)
-
 	// Initialize query values
 	queryValues := url.Values{}
-	queryValues.Add("sym", sym)
+	queryValues.Add("sym", formatSymbol(sym, FormatV3))
 
-	// Make the authenticated GET request
-	_, body, errs := bksdk.authGet(targetUrl, queryValues).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Unmarshal the response body into the respBody variable
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketMyOpenOrderV3, query: queryValues, secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
@@ -68,15 +61,18 @@ func (bksdk *SDK) MyOpenOrder(sym string) ([]response.MyOpenOrderResult, error)
 //   - response.MyOrderHistory: The response body containing the order history
 //   - error: An error if the request fails
 func (bksdk *SDK) MyOrderHistory(sym string, page, limit, start, end int) ([]response.MyOrderHistoryResult, response.BKPaginate, error) {
+	return bksdk.MyOrderHistoryCtx(context.Background(), sym, page, limit, start, end)
+}
+
+// MyOrderHistoryCtx is like MyOrderHistory but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) MyOrderHistoryCtx(ctx context.Context, sym string, page, limit, start, end int) ([]response.MyOrderHistoryResult, response.BKPaginate, error) {
 	// Initialize the response body
 	var respBody response.MyOrderHistory
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketMyOrderHistoryV3)
-
 	// Create the query string parameters
 	queVal := url.Values{}
-	queVal.Add("sym", sym)
+	queVal.Add("sym", formatSymbol(sym, FormatV3))
 
 	// Add optional parameters to the query string
 	if page != 0 {
@@ -92,23 +88,12 @@ func (bksdk *SDK) MyOrderHistory(sym string, page, limit, start, end int) ([]res
 		queVal.Add("end", strconv.Itoa(end))
 	}
 
-	// Make the authenticated GET request
-	_, body, errs := bksdk.authGet(targetUrl, queVal).End()
-	if errs != nil {
-		return respBody.Result, respBody.Pagination, errs[0]
-	}
-
-	// Unmarshal the response body into the respBody variable
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketMyOrderHistoryV3, query: queVal, secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, respBody.Pagination, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response body and nil error
 	return respBody.Result, respBody.Pagination, nil
 }
@@ -121,35 +106,27 @@ func (bksdk *SDK) MyOrderHistory(sym string, page, limit, start, end int) ([]res
 // - side: the side of the order: buy or sell
 // It returns an instance of response.OrderInfo and an error, if any.
 func (bksdk *SDK) OrderInfo(sym, orderId, side string) (response.OrderInfoResult, error) {
+	return bksdk.OrderInfoCtx(context.Background(), sym, orderId, side)
+}
+
+// OrderInfoCtx is like OrderInfo but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) OrderInfoCtx(ctx context.Context, sym, orderId, side string) (response.OrderInfoResult, error) {
 	// Initialize the response body
 	var respBody response.OrderInfo
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.MarketOrderInfoV3)
-
 	// Construct the query parameters
 	queryValues := url.Values{}
-	queryValues.Add("sym", sym)
+	queryValues.Add("sym", formatSymbol(sym, FormatV3))
 	queryValues.Add("id", orderId)
 	queryValues.Add("sd", side)
 
-	// Make the GET request and get the response
-	_, body, errs := bksdk.authGet(targetURL, queryValues).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Unmarshal the response body into respBody
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketOrderInfoV3, query: queryValues, secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if there is any error in the response
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response body and nil error
 	return respBody.Result, nil
 }
@@ -165,118 +142,86 @@ func (bksdk *SDK) OrderInfo(sym, orderId, side string) (response.OrderInfoResult
 //   - response.OrderInfo: The information about the order.
 //   - error: An error if the request fails or if there is an issue with parsing the response.
 func (bksdk *SDK) OrderInfoByHash(hash string) (response.OrderInfoResult, error) {
-	var respBody response.OrderInfo
+	return bksdk.OrderInfoByHashCtx(context.Background(), hash)
+}
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketOrderInfoV3)
+// OrderInfoByHashCtx is like OrderInfoByHash but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) OrderInfoByHashCtx(ctx context.Context, hash string) (response.OrderInfoResult, error) {
+	var respBody response.OrderInfo
 
 	// Set the query parameters
 	queVal := url.Values{}
 	queVal.Add("hash", hash)
 
-	// Send the GET request to the target URL with the query parameters
-	_, body, errs := bksdk.authGet(targetUrl, queVal).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Parse the response body and store it in respBody
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodGet, endpoint: api.MarketOrderInfoV3, query: queVal, secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if there is an error in the response body
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response body and nil error
 	return respBody.Result, nil
 }
 
 // TradingCredit retrieves the trading credit balance.
-func (bksdk *SDK) TradingCredit() (float64, error) {
+func (bksdk *SDK) TradingCredit() (decimal.Decimal, error) {
+	return bksdk.TradingCreditCtx(context.Background())
+}
+
+// TradingCreditCtx is like TradingCredit but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) TradingCreditCtx(ctx context.Context) (decimal.Decimal, error) {
 	// Create a variable to store the response body
 	var respBody response.TradingCredit
 
-	// Construct the target URL for the API endpoint
-	targetUrl := bksdk.apiHost.JoinPath(api.UserTradingCreditsV3)
-
-	// Make a POST request to the API endpoint
-	_, body, errs := bksdk.authPost(targetUrl, "").End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Unmarshal the response body into the respBody variable
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.UserTradingCreditsV3, secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the trading credit balance and no error
 	return respBody.Result, nil
 }
 
 // Limits checks deposit/withdraw limitations and usage.
 func (bksdk *SDK) Limits() (response.LimitsResult, error) {
-	var respBody response.Limits
-
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.UserLimitsV3)
+	return bksdk.LimitsCtx(context.Background())
+}
 
-	// Send a POST request to the target URL
-	_, body, errs := bksdk.authPost(targetURL, "").End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
+// LimitsCtx is like Limits but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) LimitsCtx(ctx context.Context) (response.LimitsResult, error) {
+	var respBody response.Limits
 
-	// Unmarshal the response body into the respBody struct
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.UserLimitsV3, secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if there is an error in the response
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
 // Wallet retrieves the user's available balances, including both available and reserved balances.
 // It makes a POST request to the /api/v3/market/wallet endpoint.
-// It returns the user's wallet information and any error that occurred during the API call.
+// It returns the available balance of every currency keyed by currency and any error that occurred during the API call.
 func (bksdk *SDK) Wallet() (response.WalletResult, error) {
-	var respBody response.Wallet
-
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketWalletV3)
+	return bksdk.WalletCtx(context.Background())
+}
 
-	// Make the API call
-	_, body, errs := bksdk.authPost(targetUrl, "").End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
+// WalletCtx is like Wallet but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) WalletCtx(ctx context.Context) (response.WalletResult, error) {
+	var respBody response.Wallet
 
-	// Parse the API response
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.MarketWalletV3, secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
@@ -286,29 +231,21 @@ func (bksdk *SDK) Wallet() (response.WalletResult, error) {
 // Method: POST
 // Parameter: N/A
 func (bksdk *SDK) Balances() (response.BalanceResult, error) {
+	return bksdk.BalancesCtx(context.Background())
+}
+
+// BalancesCtx is like Balances but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) BalancesCtx(ctx context.Context) (response.BalanceResult, error) {
 	// Initialize the response object
 	var respBody response.Balances
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.MarketBalancesV3)
-
-	// Send the authenticated POST request
-	_, body, errs := bksdk.authPost(targetUrl, "").End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Unmarshal the response body into the respBody struct
-	err := json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err := bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.MarketBalancesV3, secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response object
 	return respBody.Result, nil
 }
@@ -323,29 +260,21 @@ func (bksdk *SDK) Balances() (response.BalanceResult, error) {
 // - response.WsToken: the response body containing the token.
 // - error: if there was an error making the request or parsing the response.
 func (bksdk *SDK) WsToken() (token string, err error) {
+	return bksdk.WsTokenCtx(context.Background())
+}
+
+// WsTokenCtx is like WsToken but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) WsTokenCtx(ctx context.Context) (token string, err error) {
 	// Create a variable to store the response body
 	var respBody response.WsToken
 
-	// Build the target URL for the API endpoint
-	targetURL := bksdk.apiHost.JoinPath(api.MarketWstokenV3)
-
-	// Make the POST request to the API endpoint
-	_, body, errs := bksdk.authPost(targetURL, "").End()
-	if errs != nil {
-		return "", errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.MarketWstokenV3, secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return "", err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return "", errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	// Return the response body and no error
 	return respBody.Result, nil
 }
@@ -353,7 +282,13 @@ func (bksdk *SDK) WsToken() (token string, err error) {
 // InternalWithdraw makes a withdrawal to an internal address.
 // The destination address does not need to be a trusted address.
 // This API is not enabled by default. Only KYB users can request this feature by contacting us via support@bitkub.com.
-func (bksdk *SDK) CryptoInternalWithdraw(currency string, address string, memo string, amount float64) (response.InternalWithdrawResult, error) {
+func (bksdk *SDK) CryptoInternalWithdraw(currency string, address string, memo string, amount decimal.Decimal) (response.InternalWithdrawResult, error) {
+	return bksdk.CryptoInternalWithdrawCtx(context.Background(), currency, address, memo, amount)
+}
+
+// CryptoInternalWithdrawCtx is like CryptoInternalWithdraw but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) CryptoInternalWithdrawCtx(ctx context.Context, currency string, address string, memo string, amount decimal.Decimal) (response.InternalWithdrawResult, error) {
 
 	var respBody response.InternalWithdraw
 
@@ -371,26 +306,12 @@ func (bksdk *SDK) CryptoInternalWithdraw(currency string, address string, memo s
 		return respBody.Result, err
 	}
 
-	// Construct target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.CryptoInternalWithdrawV3)
-
-	// Send authenticated POST request with request body
-	_, body, errs := bksdk.authPost(targetUrl, string(reqBodyByte)).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Unmarshal response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.CryptoInternalWithdrawV3, payload: string(reqBodyByte), secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
@@ -403,6 +324,12 @@ func (bksdk *SDK) CryptoInternalWithdraw(currency string, address string, memo s
 // - response.DepositHistory: the deposit history response
 // - error: any error that occurred during the request
 func (bksdk *SDK) CryptoDepositHistory(page, limit int) ([]response.DepositHistoryResult, response.BKPaginate, error) {
+	return bksdk.CryptoDepositHistoryCtx(context.Background(), page, limit)
+}
+
+// CryptoDepositHistoryCtx is like CryptoDepositHistory but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) CryptoDepositHistoryCtx(ctx context.Context, page, limit int) ([]response.DepositHistoryResult, response.BKPaginate, error) {
 	var respBody response.DepositHistory
 
 	// Prepare the request body
@@ -417,25 +344,12 @@ func (bksdk *SDK) CryptoDepositHistory(page, limit int) ([]response.DepositHisto
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	targetUrl := bksdk.apiHost.JoinPath(api.CryptoDepositHistoryV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetUrl, string(reqBodyByte)).End()
-	if errs != nil {
-		return respBody.Result, respBody.Pagination, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.CryptoDepositHistoryV3, payload: string(reqBodyByte), secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, respBody.Pagination, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, respBody.Pagination, nil
 }
 
@@ -448,6 +362,12 @@ func (bksdk *SDK) CryptoDepositHistory(page, limit int) ([]response.DepositHisto
 // - response.WithdrawHistory: the withdraw history response
 // - error: any error that occurred during the request
 func (bksdk *SDK) CryptoWithdrawHistory(page, limit int) ([]response.WithdrawHistoryResult, response.BKPaginate, error) {
+	return bksdk.CryptoWithdrawHistoryCtx(context.Background(), page, limit)
+}
+
+// CryptoWithdrawHistoryCtx is like CryptoWithdrawHistory but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) CryptoWithdrawHistoryCtx(ctx context.Context, page, limit int) ([]response.WithdrawHistoryResult, response.BKPaginate, error) {
 	// Initialize the response variable
 	var respBody response.WithdrawHistory
 
@@ -463,40 +383,36 @@ func (bksdk *SDK) CryptoWithdrawHistory(page, limit int) ([]response.WithdrawHis
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Construct the target URL
-	targetUrl := bksdk.apiHost.JoinPath(api.CryptoWithdrawHistoryV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetUrl, string(reqBodyByte)).End()
-	if errs != nil {
-		return respBody.Result, respBody.Pagination, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.CryptoWithdrawHistoryV3, payload: string(reqBodyByte), secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, respBody.Pagination, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, respBody.Pagination, nil
 }
 
 // PlaceBid creates a buy order by sending a POST request to the /api/v3/market/place-bid endpoint.
 // It takes the following parameters:
-// - sym: string - The symbol you want to trade (e.g. btc_thb).
-// - amt: float64 - Amount you want to spend with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
-// - rat: float64 - Rate you want for the order with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
+// - sym: string - The symbol you want to trade in any convention, sent as btc_thb (see ParseSymbol).
+// - amt: decimal.Decimal - Amount you want to spend with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
+// - rat: decimal.Decimal - Rate you want for the order with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
 // - typ: string - Order type: limit or market (for market order, please specify rat as 0).
 // - client_id: string - Your id for reference (not required).
-func (bksdk *SDK) PlaceBid(sym string, amt, rat float64, typ, client_id string) (response.PlaceBidResult, error) {
+// With WithDryRun, or a ctx from DryRun, the order is sent to /api/market/place-bid/test and never created.
+func (bksdk *SDK) PlaceBid(sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceBidResult, error) {
+	return bksdk.PlaceBidCtx(context.Background(), sym, amt, rat, typ, client_id)
+}
+
+// PlaceBidCtx is like PlaceBid but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) PlaceBidCtx(ctx context.Context, sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceBidResult, error) {
 	// Initialize the response variable
 	var respBody response.PlaceBid
 
+	// Format the symbol in the v3 convention (btc_thb)
+	sym = formatSymbol(sym, FormatV3)
+
 	// Create the request body
 	reqBody := request.PlaceBid{
 		Symbol:   sym,
@@ -512,30 +428,57 @@ func (bksdk *SDK) PlaceBid(sym string, amt, rat float64, typ, client_id string)
 		return respBody.Result, err
 	}
 
-	// Convert the request body to JSON
-	reqBodyByte, err := json.Marshal(reqBody)
+	// Fit the amount and the rate to the rules of the market, see WithPrecision
+	reqBody.Amount, reqBody.Rate, err = bksdk.fitOrder(ctx, api.MarketPlaceBidV3, SideBuy, sym, typ, amt, rat)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.MarketPlaceBidV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(reqBodyByte)).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Convert the request body to JSON
+	reqBodyByte, err := json.Marshal(reqBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
+	// A dry run sends the order to the test endpoint, which never creates it
+	if bksdk.isDryRun(ctx) {
+		test, err := bksdk.placeTest(ctx, api.MarketPlaceBidTest, reqBody, sym)
+		return response.PlaceBidResult{
+			ID:   string(test.ID),
+			Hash: test.Hash,
+			Typ:  test.Typ,
+			Amt:  test.Amt,
+			Rat:  test.Rat,
+			Fee:  test.Fee,
+			Cre:  test.Cre,
+			Rec:  test.Rec,
+			Ts:   test.Ts,
+			Ci:   client_id,
+		}, err
+	}
+
+	// The order is only retried with WithOrderRetry and a client_id,
+	// an order created by a previous attempt is returned instead of being placed twice
+	c := call{method: http.MethodPost, endpoint: api.MarketPlaceBidV3, payload: string(reqBodyByte), secure: true}
+	bksdk.retryOrder(&c, sym, "buy", client_id, func(order response.MyOpenOrderResult) {
+		respBody.Result = response.PlaceBidResult{
+			ID:   order.ID,
+			Hash: order.Hash,
+			Typ:  order.Type,
+			Amt:  order.Amount,
+			Rat:  order.Rate,
+			Fee:  order.Fee,
+			Cre:  order.Credit,
+			Rec:  order.Receive,
+			Ts:   order.Ts,
+			Ci:   order.ClientID,
+		}
+	})
+
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, c, &respBody)
+	if err != nil {
+		return respBody.Result, err
 	}
 
 	return respBody.Result, nil
@@ -545,16 +488,26 @@ func (bksdk *SDK) PlaceBid(sym string, amt, rat float64, typ, client_id string)
 // Endpoint: /api/v3/market/place-ask
 // Method: POST
 // Parameters:
-// - sym: string - The symbol you want to trade (e.g. btc_thb).
-// - amt: float64 - Amount you want to spend with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
-// - rat: float64 - Rate you want for the order with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
+// - sym: string - The symbol you want to trade in any convention, sent as btc_thb (see ParseSymbol).
+// - amt: decimal.Decimal - Amount you want to spend with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
+// - rat: decimal.Decimal - Rate you want for the order with no trailing zero (e.g. 1000.00 is invalid, 1000 is ok).
 // - typ: string - Order type: limit or market (for market order, please specify rat as 0).
 // - client_id: string - Your id for reference (not required).
+// With WithDryRun, or a ctx from DryRun, the order is sent to /api/market/place-ask/test and never created.
 // It returns the response body and an error (if any).
-func (bksdk *SDK) PlaceAsk(sym string, amt, rat float64, typ, client_id string) (response.PlaceAskResult, error) {
+func (bksdk *SDK) PlaceAsk(sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceAskResult, error) {
+	return bksdk.PlaceAskCtx(context.Background(), sym, amt, rat, typ, client_id)
+}
+
+// PlaceAskCtx is like PlaceAsk but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) PlaceAskCtx(ctx context.Context, sym string, amt, rat decimal.Decimal, typ, client_id string) (response.PlaceAskResult, error) {
 	// Initialize the response variable
 	var respBody response.PlaceAsk
 
+	// Format the symbol in the v3 convention (btc_thb)
+	sym = formatSymbol(sym, FormatV3)
+
 	// Create the request body
 	reqBody := request.PlaceAsk{
 		Symbol:   sym,
@@ -570,30 +523,106 @@ func (bksdk *SDK) PlaceAsk(sym string, amt, rat float64, typ, client_id string)
 		return respBody.Result, err
 	}
 
+	// Fit the amount and the rate to the rules of the market, see WithPrecision
+	reqBody.Amount, reqBody.Rate, err = bksdk.fitOrder(ctx, api.MarketPlaceAskV3, SideSell, sym, typ, amt, rat)
+	if err != nil {
+		return respBody.Result, err
+	}
+
 	// Convert the request body to JSON
 	reqBodyByte, err := json.Marshal(reqBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.MarketPlaceAskV3)
+	// A dry run sends the order to the test endpoint, which never creates it
+	if bksdk.isDryRun(ctx) {
+		test, err := bksdk.placeTest(ctx, api.MarketPlaceAskTest, reqBody, sym)
+		return response.PlaceAskResult{
+			ID:   string(test.ID),
+			Hash: test.Hash,
+			Typ:  test.Typ,
+			Amt:  test.Amt,
+			Rat:  test.Rat,
+			Fee:  test.Fee,
+			Cre:  test.Cre,
+			Rec:  test.Rec,
+			Ts:   test.Ts,
+			Ci:   client_id,
+		}, err
+	}
+
+	// The order is only retried with WithOrderRetry and a client_id,
+	// an order created by a previous attempt is returned instead of being placed twice
+	c := call{method: http.MethodPost, endpoint: api.MarketPlaceAskV3, payload: string(reqBodyByte), secure: true}
+	bksdk.retryOrder(&c, sym, "sell", client_id, func(order response.MyOpenOrderResult) {
+		respBody.Result = response.PlaceAskResult{
+			ID:   order.ID,
+			Hash: order.Hash,
+			Typ:  order.Type,
+			Amt:  order.Amount,
+			Rat:  order.Rate,
+			Fee:  order.Fee,
+			Cre:  order.Credit,
+			Rec:  order.Receive,
+			Ts:   order.Ts,
+			Ci:   order.ClientID,
+		}
+	})
+
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, c, &respBody)
+	if err != nil {
+		return respBody.Result, err
+	}
+
+	return respBody.Result, nil
+}
+
+// Endpoint: /api/market/place-ask-by-fiat
+// Method: POST
+// Desc: Create a sell order for an amount of THB, the amount of the base currency is computed by Bitkub at the rate
+// Parameters:
+// - sym string The symbol in any convention, sent in the v1 format (e.g. THB_BTC)
+// - amt decimal.Decimal Amount of THB you want to receive
+// - rat decimal.Decimal Rate you want for the order, ignored for a market order
+// - typ string Order type: limit or market
+// This v1 endpoint is signed in the payload, it may be rejected for API keys created for the v3 endpoints only.
+func (bksdk *SDK) PlaceAskByFiat(sym string, amt, rat decimal.Decimal, typ string) (response.PlaceAskByFiatResult, error) {
+	return bksdk.PlaceAskByFiatCtx(context.Background(), sym, amt, rat, typ)
+}
+
+// PlaceAskByFiatCtx is like PlaceAskByFiat but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) PlaceAskByFiatCtx(ctx context.Context, sym string, amt, rat decimal.Decimal, typ string) (response.PlaceAskByFiatResult, error) {
+	// Initialize the response variable
+	var respBody response.PlaceAskByFiat
+
+	// Create the request body
+	reqBody := request.PlaceAskByFiat{
+		Symbol: formatSymbol(sym, FormatLegacy),
+		Amount: amt,
+		Rate:   rat,
+		Type:   typ,
+	}
 
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(reqBodyByte)).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
+	// Validate the request body
+	err := reqBody.Validate()
+	if err != nil {
+		return respBody.Result, err
 	}
 
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Convert the request body to JSON
+	reqBodyByte, err := json.Marshal(reqBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
+	// Send the request and decode the response body, the order is never retried
+	c := call{method: http.MethodPost, endpoint: api.MarketPlaceAskByFiat, payload: string(reqBodyByte), secure: true, legacy: true}
+	_, err = bksdk.do(ctx, c, &respBody)
+	if err != nil {
+		return respBody.Result, err
 	}
 
 	return respBody.Result, nil
@@ -603,17 +632,23 @@ func (bksdk *SDK) PlaceAsk(sym string, amt, rat float64, typ, client_id string)
 // Method: POST
 // Desc: Cancel an open order
 // Parameters:
-// - sym string The symbol. Please note that the current endpoint requires the symbol thb_btc. However, it will be changed to btc_thb soon and you will need to update the configurations accordingly for uninterrupted API functionality.
+// - sym string The symbol. Please note that the current endpoint requires the symbol thb_btc. However, it will be changed to btc_thb soon and you will need to update the configurations accordingly for uninterrupted API functionality. The SDK takes the symbol in any convention (see ParseSymbol) and sends it as thb_btc.
 // - id string Order id you wish to cancel
 // - sd string Order side: buy or sell
 // - hash string Cancel an order with order hash (optional). You don't need to specify sym, id, and sd when you specify order hash.
 func (bksdk *SDK) CancelOrder(sym, id, sd, hash string) (response.CancelOrder, error) {
+	return bksdk.CancelOrderCtx(context.Background(), sym, id, sd, hash)
+}
+
+// CancelOrderCtx is like CancelOrder but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) CancelOrderCtx(ctx context.Context, sym, id, sd, hash string) (response.CancelOrder, error) {
 	// Initialize the response variable
 	var respBody response.CancelOrder
 
 	// Create the request body
 	reqBody := request.CancelOrder{
-		Symbol: sym,
+		Symbol: formatSymbol(sym, FormatStream),
 		ID:     id,
 		Side:   sd,
 		Hash:   hash,
@@ -631,26 +666,12 @@ func (bksdk *SDK) CancelOrder(sym, id, sd, hash string) (response.CancelOrder, e
 		return respBody, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.MarketCancelOrderV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.MarketCancelOrderV3, payload: string(jsonReqBody), secure: true}, &respBody)
 	if err != nil {
 		return respBody, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody, nil
 }
 
@@ -659,6 +680,12 @@ func (bksdk *SDK) CancelOrder(sym, id, sd, hash string) (response.CancelOrder, e
 // with optional pagination parameters (page and limit).
 // It returns a response containing the crypto addresses and an error, if any.
 func (bksdk *SDK) CryptoAddresses(page, limit int) ([]response.CryptoAddressesResult, response.BKPaginate, error) {
+	return bksdk.CryptoAddressesCtx(context.Background(), page, limit)
+}
+
+// CryptoAddressesCtx is like CryptoAddresses but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) CryptoAddressesCtx(ctx context.Context, page, limit int) ([]response.CryptoAddressesResult, response.BKPaginate, error) {
 	// Initialize the response variable
 	var respBody response.CryptoAddresses
 
@@ -674,26 +701,12 @@ func (bksdk *SDK) CryptoAddresses(page, limit int) ([]response.CryptoAddressesRe
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.CryptoAddressesV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody.Result, respBody.Pagination, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.CryptoAddressesV3, payload: string(jsonReqBody), secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, respBody.Pagination, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, respBody.Pagination, nil
 }
 
@@ -708,6 +721,12 @@ func (bksdk *SDK) CryptoAddresses(page, limit int) ([]response.CryptoAddressesRe
 // - response: The generated address and other relevant information.
 // - error: An error if the request fails or if there is an issue parsing the response.
 func (bksdk *SDK) CryptoGenerateAddress(symbol string) ([]response.CryptoGenerateAddressResult, error) {
+	return bksdk.CryptoGenerateAddressCtx(context.Background(), symbol)
+}
+
+// CryptoGenerateAddressCtx is like CryptoGenerateAddress but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) CryptoGenerateAddressCtx(ctx context.Context, symbol string) ([]response.CryptoGenerateAddressResult, error) {
 	// Initialize the response variable
 	var respBody response.CryptoGenerateAddress
 
@@ -725,26 +744,12 @@ func (bksdk *SDK) CryptoGenerateAddress(symbol string) ([]response.CryptoGenerat
 		return respBody.Result, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.CryptoGenerateAddressV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.CryptoGenerateAddressV3, payload: string(jsonReqBody), secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
@@ -766,7 +771,13 @@ func (bksdk *SDK) CryptoGenerateAddress(symbol string) ([]response.CryptoGenerat
 // Returns:
 // - response: The response body with the withdrawal details
 // - error: An error if the withdrawal request fails
-func (bksdk *SDK) CryptoWithdraw(currency string, address string, memo string, amount float64, network string) (response.CryptoWithdrawResult, error) {
+func (bksdk *SDK) CryptoWithdraw(currency string, address string, memo string, amount decimal.Decimal, network string) (response.CryptoWithdrawResult, error) {
+	return bksdk.CryptoWithdrawCtx(context.Background(), currency, address, memo, amount, network)
+}
+
+// CryptoWithdrawCtx is like CryptoWithdraw but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) CryptoWithdrawCtx(ctx context.Context, currency string, address string, memo string, amount decimal.Decimal, network string) (response.CryptoWithdrawResult, error) {
 	// Initialize the response variable
 	var respBody response.CryptoWithdraw
 
@@ -785,26 +796,12 @@ func (bksdk *SDK) CryptoWithdraw(currency string, address string, memo string, a
 		return respBody.Result, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.CryptoWithdrawV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.CryptoWithdrawV3, payload: string(jsonReqBody), secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
@@ -818,6 +815,12 @@ func (bksdk *SDK) CryptoWithdraw(currency string, address string, memo string, a
 // - response.FiatAccounts: List of approved bank accounts
 // - error: Error if any occurs
 func (bksdk *SDK) FiatAccounts(page int, limit int) ([]response.FiatAccountsResult, response.BKPaginate, error) {
+	return bksdk.FiatAccountsCtx(context.Background(), page, limit)
+}
+
+// FiatAccountsCtx is like FiatAccounts but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) FiatAccountsCtx(ctx context.Context, page int, limit int) ([]response.FiatAccountsResult, response.BKPaginate, error) {
 	// Initialize the response variable
 	var respBody response.FiatAccounts
 
@@ -833,26 +836,12 @@ func (bksdk *SDK) FiatAccounts(page int, limit int) ([]response.FiatAccountsResu
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.FiatAccountsV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody.Result, respBody.Pagination, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.FiatAccountsV3, payload: string(jsonReqBody), secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, respBody.Pagination, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, respBody.Pagination, nil
 }
 
@@ -860,11 +849,17 @@ func (bksdk *SDK) FiatAccounts(page int, limit int) ([]response.FiatAccountsResu
 // It sends a POST request to the /api/v3/fiat/withdraw endpoint.
 // Parameters:
 // - id: string, the bank account id
-// - amt: float64, the amount to withdraw
+// - amt: decimal.Decimal, the amount to withdraw
 // Returns:
 // - response.FiatWithdraw: the response body
 // - error: any error that occurred during the request
-func (bksdk *SDK) FiatWithdraw(id string, amt float64) (response.FiatWithdrawResult, error) {
+func (bksdk *SDK) FiatWithdraw(id string, amt decimal.Decimal) (response.FiatWithdrawResult, error) {
+	return bksdk.FiatWithdrawCtx(context.Background(), id, amt)
+}
+
+// FiatWithdrawCtx is like FiatWithdraw but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) FiatWithdrawCtx(ctx context.Context, id string, amt decimal.Decimal) (response.FiatWithdrawResult, error) {
 	// Initialize the response variable
 	var respBody response.FiatWithdraw
 
@@ -880,26 +875,12 @@ func (bksdk *SDK) FiatWithdraw(id string, amt float64) (response.FiatWithdrawRes
 		return respBody.Result, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.FiatWithdrawV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody.Result, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.FiatWithdrawV3, payload: string(jsonReqBody), secure: true}, &respBody)
 	if err != nil {
 		return respBody.Result, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, nil
 }
 
@@ -912,6 +893,12 @@ func (bksdk *SDK) FiatWithdraw(id string, amt float64) (response.FiatWithdrawRes
 // Function: FiatDepositHistory retrieves the fiat deposit history using the specified page and limit.
 // It returns a response object containing the deposit history and an error if any.
 func (bksdk *SDK) FiatDepositHistory(page, limit int) ([]response.FiatDepositHistoryResult, response.BKPaginate, error) {
+	return bksdk.FiatDepositHistoryCtx(context.Background(), page, limit)
+}
+
+// FiatDepositHistoryCtx is like FiatDepositHistory but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) FiatDepositHistoryCtx(ctx context.Context, page, limit int) ([]response.FiatDepositHistoryResult, response.BKPaginate, error) {
 	// Initialize the response variable
 	var respBody response.FiatDepositHistory
 
@@ -927,26 +914,12 @@ func (bksdk *SDK) FiatDepositHistory(page, limit int) ([]response.FiatDepositHis
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.FiatDepositHistoryV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody.Result, respBody.Pagination, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.FiatDepositHistoryV3, payload: string(jsonReqBody), secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, respBody.Pagination, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, respBody.Pagination, nil
 }
 
@@ -960,6 +933,12 @@ func (bksdk *SDK) FiatDepositHistory(page, limit int) ([]response.FiatDepositHis
 // - response.FiatWithdrawHistory: The response body containing the fiat withdrawal history
 // - error: Any error that occurred during the API request
 func (bksdk *SDK) FiatWithdrawHistory(page, limit int) ([]response.FiatWithdrawHistoryResult, response.BKPaginate, error) {
+	return bksdk.FiatWithdrawHistoryCtx(context.Background(), page, limit)
+}
+
+// FiatWithdrawHistoryCtx is like FiatWithdrawHistory but sends the request with ctx,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) FiatWithdrawHistoryCtx(ctx context.Context, page, limit int) ([]response.FiatWithdrawHistoryResult, response.BKPaginate, error) {
 	// Initialize the response variable
 	var respBody response.FiatWithdrawHistory
 
@@ -975,25 +954,11 @@ func (bksdk *SDK) FiatWithdrawHistory(page, limit int) ([]response.FiatWithdrawH
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Construct the target URL
-	targetURL := bksdk.apiHost.JoinPath(api.FiatWithdrawHistoryV3)
-
-	// Send the authenticated POST request with the request body
-	_, body, errs := bksdk.authPost(targetURL, string(jsonReqBody)).End()
-	if errs != nil {
-		return respBody.Result, respBody.Pagination, errs[0]
-	}
-
-	// Parse the response body
-	err = json.Unmarshal([]byte(body), &respBody)
+	// Send the request and decode the response body
+	_, err = bksdk.do(ctx, call{method: http.MethodPost, endpoint: api.FiatWithdrawHistoryV3, payload: string(jsonReqBody), secure: true, idempotent: true}, &respBody)
 	if err != nil {
 		return respBody.Result, respBody.Pagination, err
 	}
 
-	// Check if the response body contains an error
-	if respBody.Error != 0 {
-		return respBody.Result, respBody.Pagination, errors.New(bkerr.ErrorText(respBody.Error))
-	}
-
 	return respBody.Result, respBody.Pagination, nil
 }
@@ -0,0 +1,110 @@
+package bksdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+)
+
+// NormalizeSymbol returns the symbol in the format of the websocket streams,
+// lower case with an underscore between the currencies (e.g. "THB-BTC" and "thb/btc" give "thb_btc").
+func NormalizeSymbol(symbol string) string {
+	symbol = strings.ToLower(strings.TrimSpace(symbol))
+	return strings.NewReplacer("-", "_", "/", "_").Replace(symbol)
+}
+
+// TickerStream returns the name of the ticker stream of a symbol in any convention (e.g. market.ticker.thb_btc for btc_thb).
+func TickerStream(symbol string) string {
+	return fmt.Sprintf(WS_TICKER_STREAM, NormalizeSymbol(formatSymbol(symbol, FormatStream)))
+}
+
+// TradeStream returns the name of the trade stream of a symbol in any convention (e.g. market.trade.thb_btc for btc_thb).
+func TradeStream(symbol string) string {
+	return fmt.Sprintf(WS_TRADE_STREAM, NormalizeSymbol(formatSymbol(symbol, FormatStream)))
+}
+
+// OrderBookStream returns the name of the order book stream of a pairing id from GetSymbols (e.g. orderbook/1).
+func OrderBookStream(pairingID int) string {
+	return fmt.Sprintf(WS_ORDERBOOK_STREAM, pairingID)
+}
+
+// TickerStreams returns the ticker streams of the symbols, checked against GetSymbols.
+// Symbols may use any case and either order of the currencies (thb_btc or BTC_THB).
+// Without symbols it returns the ticker stream of every market.
+func (bksdk *SDK) TickerStreams(ctx context.Context, symbols ...string) ([]string, error) {
+	return bksdk.streams(ctx, symbols, func(sym string, _ int) string {
+		return TickerStream(sym)
+	})
+}
+
+// TradeStreams is like TickerStreams but returns the trade streams of the symbols.
+func (bksdk *SDK) TradeStreams(ctx context.Context, symbols ...string) ([]string, error) {
+	return bksdk.streams(ctx, symbols, func(sym string, _ int) string {
+		return TradeStream(sym)
+	})
+}
+
+// OrderBookStreams is like TickerStreams but returns the order book streams of the symbols,
+// named after the pairing id of the symbols.
+func (bksdk *SDK) OrderBookStreams(ctx context.Context, symbols ...string) ([]string, error) {
+	return bksdk.streams(ctx, symbols, func(_ string, id int) string {
+		return OrderBookStream(id)
+	})
+}
+
+// streams resolves the symbols with GetSymbols and builds their stream names.
+// Every unknown symbol is listed in the returned error, which wraps bkerr.ErrUnknownSymbol.
+func (bksdk *SDK) streams(ctx context.Context, symbols []string, build func(sym string, id int) string) ([]string, error) {
+	// Index the markets by normalized symbol
+	markets, err := bksdk.GetSymbolsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int, len(markets))
+	for _, market := range markets {
+		ids[NormalizeSymbol(market.Symbol)] = market.ID
+	}
+
+	// Every market when no symbol is given
+	if len(symbols) == 0 {
+		for _, market := range markets {
+			symbols = append(symbols, market.Symbol)
+		}
+	}
+
+	// Resolve each symbol, a currency missing from QuoteCurrencies may be in either order
+	var streams, unknown []string
+	for _, symbol := range symbols {
+		sym := NormalizeSymbol(formatSymbol(symbol, FormatStream))
+		id, ok := ids[sym]
+		if !ok {
+			sym = reverseSymbol(sym)
+			id, ok = ids[sym]
+		}
+		if !ok {
+			unknown = append(unknown, symbol)
+			continue
+		}
+
+		stream := build(sym, id)
+		if !contains(streams, stream) {
+			streams = append(streams, stream)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: %s", bkerr.ErrUnknownSymbol, strings.Join(unknown, ", "))
+	}
+	return streams, nil
+}
+
+// reverseSymbol swaps the currencies of a normalized symbol (btc_thb gives thb_btc).
+func reverseSymbol(symbol string) string {
+	base, quote, ok := strings.Cut(symbol, "_")
+	if !ok {
+		return symbol
+	}
+	return quote + "_" + base
+}
@@ -0,0 +1,98 @@
+package bksdk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+)
+
+// QuoteCurrencies lists the quote currencies of the Bitkub markets.
+// ParseSymbol uses it to tell the base from the quote currency, whatever their order.
+var QuoteCurrencies = []string{"THB"}
+
+// SymbolFormat is the convention of an endpoint for the symbols of the markets.
+type SymbolFormat int
+
+const (
+	FormatV3      SymbolFormat = iota // btc_thb: the secure v3 endpoints
+	FormatHistory                     // BTC_THB: GetHistory
+	FormatStream                      // thb_btc: the websocket streams, the public market endpoints and CancelOrder
+	FormatLegacy                      // THB_BTC: the v1 endpoints (PlaceAskByFiat and the dry run endpoints)
+)
+
+// Symbol is a market, e.g. Base BTC and Quote THB for the market of Bitcoin in Thai Baht.
+// The SDK formats it in the convention of each endpoint, see SymbolFormat.
+type Symbol struct {
+	Base  string // Currency bought and sold, in upper case (e.g. BTC)
+	Quote string // Currency of the rates, in upper case (e.g. THB)
+}
+
+// ParseSymbol parses a symbol in any of the conventions of the API: btc_thb, BTC_THB, thb_btc or THB_BTC.
+// The currencies may also be separated by "-" or "/". The quote currency is the one listed in QuoteCurrencies,
+// when neither is listed the symbol is read as base_quote.
+// A malformed symbol returns a *bkerr.APIError matching bkerr.ErrInvalidSymbol, as the API would.
+func ParseSymbol(symbol string) (Symbol, error) {
+	first, second, ok := strings.Cut(strings.ToUpper(NormalizeSymbol(symbol)), "_")
+	if !ok || !isCurrency(first) || !isCurrency(second) {
+		return Symbol{}, &bkerr.APIError{Code: bkerr.InvalidSymbol, Message: fmt.Sprintf("%s: %q", bkerr.ErrorText(bkerr.InvalidSymbol), symbol)}
+	}
+
+	// The quote currency may come first (THB_BTC) or last (BTC_THB)
+	if isQuoteCurrency(first) && !isQuoteCurrency(second) {
+		return Symbol{Base: second, Quote: first}, nil
+	}
+	return Symbol{Base: first, Quote: second}, nil
+}
+
+// Format returns the symbol in a convention of the API.
+func (s Symbol) Format(format SymbolFormat) string {
+	switch format {
+	case FormatHistory:
+		return s.Base + "_" + s.Quote
+	case FormatStream:
+		return strings.ToLower(s.Quote + "_" + s.Base)
+	case FormatLegacy:
+		return s.Quote + "_" + s.Base
+	default:
+		return strings.ToLower(s.Base + "_" + s.Quote)
+	}
+}
+
+// String returns the symbol in the convention of the secure v3 endpoints (e.g. btc_thb).
+func (s Symbol) String() string {
+	return s.Format(FormatV3)
+}
+
+// formatSymbol returns a symbol in the convention of an endpoint.
+// A symbol that does not parse is returned as is, so the endpoint reports it.
+func formatSymbol(symbol string, format SymbolFormat) string {
+	sym, err := ParseSymbol(symbol)
+	if err != nil {
+		return symbol
+	}
+	return sym.Format(format)
+}
+
+// isCurrency reports whether a part of a symbol is a currency code.
+func isCurrency(code string) bool {
+	if code == "" {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isQuoteCurrency reports whether a currency is listed in QuoteCurrencies.
+func isQuoteCurrency(code string) bool {
+	for _, quote := range QuoteCurrencies {
+		if strings.EqualFold(code, quote) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package bksdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+)
+
+// DefaultSymbolRefreshInterval is how long the metadata of the markets is cached before it is loaded again.
+const DefaultSymbolRefreshInterval = time.Hour
+
+// PrecisionPolicy selects what PlaceBid and PlaceAsk do with an order that does not fit the rules of its market.
+type PrecisionPolicy int
+
+const (
+	// PrecisionOff sends the orders as they are, Bitkub rejects the orders that do not fit.
+	PrecisionOff PrecisionPolicy = iota
+
+	// PrecisionReject rejects an order whose rate is not a multiple of the tick size, whose amount has more
+	// decimals than the market allows, or whose value is below the minimum, before it is sent.
+	PrecisionReject
+
+	// PrecisionRound rounds the rate to the tick size (down for a bid, up for an ask) and the amount down to
+	// the decimals the market allows, then rejects the order if its value is below the minimum.
+	PrecisionRound
+)
+
+// WithPrecision checks the orders of PlaceBid and PlaceAsk against the metadata of their market from
+// GetMarketSymbols before they are sent. The rejected orders return the same errors as Bitkub:
+// bkerr.ErrImproperRate, bkerr.ErrInvalidAmount and bkerr.ErrAmountTooLow, with a descriptive message.
+func WithPrecision(policy PrecisionPolicy) Option {
+	return func(bksdk *SDK) error {
+		bksdk.precision = policy
+		return nil
+	}
+}
+
+// WithSymbolRefreshInterval sets how long the metadata of the markets is cached before it is loaded again.
+func WithSymbolRefreshInterval(interval time.Duration) Option {
+	return func(bksdk *SDK) error {
+		if interval <= 0 {
+			return fmt.Errorf("invalid symbol refresh interval %s", interval)
+		}
+		bksdk.symbols.interval = interval
+		return nil
+	}
+}
+
+// symbolRegistry caches the metadata of the markets by normalized symbol (e.g. btc_thb).
+// The mutex only guards the fields, the markets are fetched without holding it.
+type symbolRegistry struct {
+	interval time.Duration
+	fetch    func(ctx context.Context) ([]response.SymbolInfo, error)
+
+	mu       sync.Mutex
+	symbols  map[string]response.SymbolInfo
+	loadedAt time.Time
+	pending  chan struct{} // Closed when the load in flight ends, nil without one
+}
+
+// newSymbolRegistry creates the registry of the SDK, the metadata is loaded on the first lookup.
+func newSymbolRegistry(bksdk *SDK) *symbolRegistry {
+	return &symbolRegistry{
+		interval: DefaultSymbolRefreshInterval,
+		fetch:    bksdk.GetMarketSymbolsCtx,
+	}
+}
+
+// lookup returns the metadata of a symbol, loading the markets again when the cache is older than the interval.
+// The cache is kept when loading fails, unless it is older than twice the interval.
+// A single call loads the markets at a time: the other calls use the cache while it is usable,
+// or wait for the load in flight until their own ctx is done.
+func (r *symbolRegistry) lookup(ctx context.Context, sym string) (response.SymbolInfo, error) {
+	symbols, err := r.current(ctx)
+	if err != nil {
+		return response.SymbolInfo{}, err
+	}
+
+	info, ok := symbols[NormalizeSymbol(formatSymbol(sym, FormatV3))]
+	if !ok {
+		return response.SymbolInfo{}, fmt.Errorf("%w: %s", bkerr.ErrUnknownSymbol, sym)
+	}
+	return info, nil
+}
+
+// current returns the cached markets, loading them again when they are older than the interval.
+func (r *symbolRegistry) current(ctx context.Context) (map[string]response.SymbolInfo, error) {
+	for {
+		r.mu.Lock()
+		if !r.due(r.interval) {
+			defer r.mu.Unlock()
+			return r.symbols, nil
+		}
+
+		// Another call is loading the markets
+		if pending := r.pending; pending != nil {
+			if !r.due(2 * r.interval) {
+				defer r.mu.Unlock()
+				return r.symbols, nil
+			}
+			r.mu.Unlock()
+
+			select {
+			case <-pending:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		// Load the markets without holding the lock
+		pending := make(chan struct{})
+		r.pending = pending
+		r.mu.Unlock()
+
+		symbols, err := r.load(ctx)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.pending = nil
+		close(pending)
+		if err == nil {
+			r.symbols, r.loadedAt = symbols, time.Now()
+		} else if r.due(2 * r.interval) {
+			return nil, err
+		}
+		return r.symbols, nil
+	}
+}
+
+// due reports whether the cache is older than the age, or whether the markets were never loaded.
+// The caller holds the lock.
+func (r *symbolRegistry) due(age time.Duration) bool {
+	return r.symbols == nil || time.Since(r.loadedAt) >= age
+}
+
+// load fetches the markets of GetMarketSymbols by normalized symbol.
+func (r *symbolRegistry) load(ctx context.Context) (map[string]response.SymbolInfo, error) {
+	markets, err := r.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make(map[string]response.SymbolInfo, len(markets))
+	for _, market := range markets {
+		symbols[NormalizeSymbol(formatSymbol(market.Symbol, FormatV3))] = market
+	}
+	return symbols, nil
+}
+
+// SymbolInfo returns the metadata of a market in any convention (e.g. btc_thb or THB_BTC) from a cache of GetMarketSymbols,
+// refreshed every DefaultSymbolRefreshInterval (see WithSymbolRefreshInterval).
+// An unknown symbol returns bkerr.ErrUnknownSymbol.
+func (bksdk *SDK) SymbolInfo(sym string) (response.SymbolInfo, error) {
+	return bksdk.SymbolInfoCtx(context.Background(), sym)
+}
+
+// SymbolInfoCtx is like SymbolInfo but loads the markets with ctx when the cache is out of date,
+// so cancelling ctx or reaching its deadline aborts the call.
+func (bksdk *SDK) SymbolInfoCtx(ctx context.Context, sym string) (response.SymbolInfo, error) {
+	return bksdk.symbols.lookup(ctx, sym)
+}
+
+// fitOrder applies the precision policy to the amount and rate of an order before it is sent.
+// The amount of a bid is in the quote currency, the amount of an ask is in the base currency.
+func (bksdk *SDK) fitOrder(ctx context.Context, endpoint string, side Side, sym, typ string, amt, rat decimal.Decimal) (decimal.Decimal, decimal.Decimal, error) {
+	if bksdk.precision == PrecisionOff {
+		return amt, rat, nil
+	}
+
+	info, err := bksdk.SymbolInfoCtx(ctx, sym)
+	if err != nil {
+		return amt, rat, err
+	}
+	round := bksdk.precision == PrecisionRound
+	reject := func(code int, format string, args ...any) error {
+		return &bkerr.APIError{Code: code, Message: fmt.Sprintf(bkerr.ErrorText(code)+": "+format, args...), Endpoint: endpoint}
+	}
+
+	// The rate of a limit order is a multiple of the tick size
+	if typ == string(OrderLimit) && info.PriceStep.IsPositive() {
+		fitted := toStep(rat, info.PriceStep, side == SideSell)
+		if !fitted.Equal(rat) && !round {
+			return amt, rat, reject(bkerr.ImproperRate, "%s is not a multiple of the tick size %s", rat, info.PriceStep)
+		}
+		rat = fitted
+	}
+
+	// The amount has the decimals of its currency, the amount of the base currency is a multiple of the lot size
+	var fitted decimal.Decimal
+	if side == SideBuy {
+		fitted = amt.Truncate(int32(info.QuoteAssetScale))
+	} else {
+		fitted = amt
+		if info.VolumeStep.IsPositive() {
+			fitted = toStep(amt, info.VolumeStep, false)
+		}
+	}
+	if !fitted.Equal(amt) && !round {
+		return amt, rat, reject(bkerr.InvalidAmount, "%s does not fit the precision of %s", amt, sym)
+	}
+	amt = fitted
+
+	// The value of the order is at least the minimum, the value of a market ask is only known by Bitkub
+	value := amt
+	if side == SideSell {
+		value = amt.Mul(rat)
+	}
+	if info.MinQuoteSize.IsPositive() && (side == SideBuy || typ == string(OrderLimit)) && value.LessThan(info.MinQuoteSize) {
+		return amt, rat, reject(bkerr.AmountTooLow, "the value %s %s is below the minimum %s", value, info.QuoteAsset, info.MinQuoteSize)
+	}
+
+	return amt, rat, nil
+}
+
+// toStep rounds a positive value to a multiple of step, down or up.
+func toStep(value, step decimal.Decimal, up bool) decimal.Decimal {
+	steps := value.Div(step, 18).Truncate(0)
+	fitted := steps.Mul(step)
+	if up && fitted.LessThan(value) {
+		fitted = fitted.Add(step)
+	}
+	return fitted
+}
@@ -1,13 +1,16 @@
 package bksdk
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
-
-	"github.com/parnurzeal/gorequest"
+	"strconv"
+	"strings"
 )
 
 // generateSignature generates a signature pattern for Bitkub API.
@@ -37,48 +40,168 @@ func (bksdk *SDK) generateSignature(timestamp, method, endpoint, bodyPayload str
 
 // authGet is a function that wraps a request before sending it to secure endpoints.
 // It performs the following steps:
-// 1. Retrieves the server time before generating the signature.
+// 1. Takes the timestamp from the clock synchronised with the server time.
 // 2. Generates the signature using the timestamp, method, endpoint, and payloads.
 // 3. Sets the headers with the generated key and API key.
 //
 // Parameters:
-// - targetUrl: The target URL for the request.
+// - ctx: The context of the request, also used when the clock has to be synchronised.
+// - endpoint: The API path of the endpoint, one of the constants in the api package.
 // - queryValues: The query values for the request.
 //
 // Returns:
-// - A *gorequest.SuperAgent object representing the authenticated GET request.
-func (bksdk *SDK) authGet(targetUrl *url.URL, queryValues url.Values) *gorequest.SuperAgent {
-	// Retrieve the server time
-	ts, _ := bksdk.GetServerTime()
+// - A *http.Request representing the authenticated GET request.
+// - An error if the clock could not be synchronised with the server time.
+func (bksdk *SDK) authGet(ctx context.Context, endpoint string, queryValues url.Values) (*http.Request, error) {
+	// Take the timestamp from the synchronised clock
+	ts, err := bksdk.clock.timestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate the signature
-	sig := bksdk.generateSignature(ts, "GET", "/"+targetUrl.Path, "?"+queryValues.Encode())
-
-	// Set the headers and query values of the request
-	return bksdk.req.Get(targetUrl.String()).
-		Set("X-BTK-TIMESTAMP", ts).
-		Set("X-BTK-APIKEY", bksdk.apiKey).
-		Set("X-BTK-SIGN", sig).
-		Set("Content-Type", "application/json").Query(queryValues.Encode())
+	sig := bksdk.generateSignature(ts, http.MethodGet, endpoint, "?"+queryValues.Encode())
+
+	// Create the request with the query values
+	req, err := bksdk.newRequest(ctx, http.MethodGet, endpoint, queryValues, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the headers of the request
+	req.Header.Set("X-BTK-TIMESTAMP", ts)
+	req.Header.Set("X-BTK-APIKEY", bksdk.apiKey)
+	req.Header.Set("X-BTK-SIGN", sig)
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
 }
 
 // authPost is a function that wraps a request before sending it to secure endpoints.
-// It generates a signature using the server time, method, endpoint, and payload,
+// It generates a signature using the synchronised timestamp, method, endpoint, and payload,
 // and sets the necessary headers for authentication.
-func (bksdk *SDK) authPost(targetUrl *url.URL, jsonPayload string) *gorequest.SuperAgent {
-	// Step 1 - Get server time before generating the signature
-	ts, _ := bksdk.GetServerTime()
+// The payload is sent exactly as it was signed.
+// It returns an error if the clock could not be synchronised with the server time.
+func (bksdk *SDK) authPost(ctx context.Context, endpoint string, jsonPayload string) (*http.Request, error) {
+	// Step 1 - Take the timestamp from the synchronised clock
+	ts, err := bksdk.clock.timestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Step 2 - Generate the signature with the timestamp, method, endpoint, and payloads
-	sig := bksdk.generateSignature(ts, "POST", "/"+targetUrl.Path, jsonPayload)
-
-	// Step 3 - Set the headers with the generated key and API key
-	return bksdk.req.Post(targetUrl.String()).
-		Set("X-BTK-TIMESTAMP", ts).
-		Set("X-BTK-APIKEY", bksdk.apiKey).
-		Set("X-BTK-SIGN", sig).
-		Set("Content-Type", "application/json").
-		Send(jsonPayload)
+	sig := bksdk.generateSignature(ts, http.MethodPost, endpoint, jsonPayload)
+
+	// Step 3 - Create the request and set the headers with the generated key and API key
+	req, err := bksdk.newRequest(ctx, http.MethodPost, endpoint, nil, jsonPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-BTK-TIMESTAMP", ts)
+	req.Header.Set("X-BTK-APIKEY", bksdk.apiKey)
+	req.Header.Set("X-BTK-SIGN", sig)
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// authPostLegacy wraps a request to the v1 secure endpoints, which are signed in the payload:
+// the timestamp in seconds is added to the JSON payload as ts, then the HMAC SHA-256 of the payload
+// is added as sig. Only the API key is sent in the headers.
+// It returns an error if the clock could not be synchronised with the server time.
+func (bksdk *SDK) authPostLegacy(ctx context.Context, endpoint string, jsonPayload string) (*http.Request, error) {
+	// Step 1 - Take the timestamp in seconds from the synchronised clock
+	ts, err := bksdk.clock.timestamp(ctx)
+	if err != nil {
+		return nil, err
+	}
+	millis, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 2 - Add the timestamp to the payload, then sign the payload and add the signature
+	jsonPayload = appendJSONField(jsonPayload, "ts", strconv.FormatInt(millis/1000, 10))
+	sig := bksdk.generateSignature("", "", "", jsonPayload)
+	jsonPayload = appendJSONField(jsonPayload, "sig", strconv.Quote(sig))
+
+	// Step 3 - Create the request and set the headers with the API key
+	req, err := bksdk.newRequest(ctx, http.MethodPost, endpoint, nil, jsonPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-BTK-APIKEY", bksdk.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// appendJSONField adds a field with a raw JSON value at the end of a JSON object.
+func appendJSONField(object, key, value string) string {
+	object = strings.TrimSuffix(strings.TrimSpace(object), "}")
+	if strings.TrimSpace(object) != "{" {
+		object += ","
+	}
+	return object + strconv.Quote(key) + ":" + value + "}"
+}
+
+// newRequest creates a request to the endpoint on the API host.
+// A new request is created for every call, so nothing is shared between goroutines.
+func (bksdk *SDK) newRequest(ctx context.Context, method, endpoint string, queryValues url.Values, payload string) (*http.Request, error) {
+	// Build the target URL
+	targetURL := bksdk.apiHost.JoinPath(endpoint)
+	if len(queryValues) > 0 {
+		targetURL.RawQuery = queryValues.Encode()
+	}
+
+	// Only POST requests carry a body
+	var body io.Reader
+	if payload != "" {
+		body = strings.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, targetURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the user agent
+	if bksdk.userAgent != "" {
+		req.Header.Set("User-Agent", bksdk.userAgent)
+	}
+
+	return req, nil
+}
+
+// send sends the request with the SDK's http client and reads the whole response body,
+// so the client, timeout and user agent configured through the options are always applied.
+func (bksdk *SDK) send(req *http.Request) (*http.Response, []byte, error) {
+	// Send the request
+	resp, err := bksdk.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, nil, err
+	}
+
+	return resp, body, nil
+}
+
+// newTransport creates the pooled transport used when no http client is given.
+// Connections are kept alive and shared by every goroutine using the SDK.
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 32
+	return transport
 }
 
 // PrettyStruct prints a pretty JSON representation of a struct.
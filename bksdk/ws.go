@@ -10,6 +10,7 @@ import (
 const WS_HOST = "wss://api.bitkub.com/websocket-api/"
 const WS_TICKER_STREAM = "market.ticker.%s"
 const WS_TRADE_STREAM = "market.trade.%s"
+const WS_ORDERBOOK_STREAM = "orderbook/%d"
 
 // CreateWsConnection creates a websocket connection.
 //
@@ -17,15 +18,31 @@ const WS_TRADE_STREAM = "market.trade.%s"
 // - streamName: The name of the stream.
 // - reader: A channel used to read messages from the websocket.
 // - ctx: The context object for managing the connection's lifecycle.
+//
+// Deprecated: CreateWsConnection does not reconnect and mixes errors with messages, use NewWsClient instead.
 func CreateWsConnection(streamName string, reader chan string, ctx context.Context) {
+	createWsConnection(WS_HOST, streamName, reader, ctx)
+}
+
+// CreateWsConnection creates a websocket connection to the websocket host configured
+// on the SDK (see WithWSHost). It behaves like the package level CreateWsConnection.
+//
+// Deprecated: use NewWsClient instead.
+func (bksdk *SDK) CreateWsConnection(streamName string, reader chan string, ctx context.Context) {
+	createWsConnection(bksdk.wsHost, streamName, reader, ctx)
+}
+
+// createWsConnection dials wsHost + streamName and pushes every message to the reader.
+func createWsConnection(wsHost, streamName string, reader chan string, ctx context.Context) {
 
 	// Create stream name
-	streamName = WS_HOST + streamName
+	streamName = wsHost + streamName
 
 	// Create a new websocket connection
 	conn, _, err := websocket.DefaultDialer.Dial(streamName, nil)
 	if err != nil {
 		reader <- fmt.Sprintf("Failed to connect to websocket: %s", err.Error())
+		return
 	}
 
 	// Start a goroutine to read messages from the websocket
@@ -39,6 +56,7 @@ func CreateWsConnection(streamName string, reader chan string, ctx context.Conte
 					return
 				}
 				reader <- fmt.Sprintf("Error reading message from websocket: %s", err.Error())
+				return
 			}
 
 			// Send the message to the channel and continue reading
@@ -0,0 +1,785 @@
+package bksdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+)
+
+const (
+	// DefaultWsPingInterval is the interval between two pings sent to keep the connection alive.
+	DefaultWsPingInterval = 30 * time.Second
+
+	// DefaultWsBufferSize is the capacity of the message channels of a websocket client.
+	DefaultWsBufferSize = 256
+
+	// DefaultWsMaxStreams is the number of streams of a connection, larger subscriptions are sharded
+	// across several connections.
+	DefaultWsMaxStreams = 50
+
+	// wsMaxURLLength is the maximum length of the URL of a connection, larger subscriptions are sharded
+	// across several connections.
+	wsMaxURLLength = 2000
+
+	// wsWriteWait is the time allowed to write a control message.
+	wsWriteWait = 10 * time.Second
+)
+
+// DefaultWsReconnectPolicy reconnects forever with an exponential backoff from 500ms up to 30s.
+// MaxAttempts is the number of consecutive failed connections after which the client gives up, 0 means never.
+var DefaultWsReconnectPolicy = RetryPolicy{
+	BaseDelay: 500 * time.Millisecond,
+	MaxDelay:  30 * time.Second,
+	Jitter:    0.2,
+}
+
+// errRenew stops serving a connection so the client reconnects right away.
+var errRenew = errors.New("renew connection")
+
+// Stream kinds, the stream name without its symbol.
+const (
+	wsTickerKind = "market.ticker"
+	wsTradeKind  = "market.trade"
+
+	// Messages of the order book stream have no stream name, they are routed by their pairing id
+	wsOrderBookKind = "orderbook"
+)
+
+// wsDecoder decodes a message of a stream kind and delivers it to its channel.
+// ctx is the context of the shard that read the message, a blocked delivery gives up when it is done.
+type wsDecoder func(ctx context.Context, message []byte) error
+
+// WsOption configures a websocket client created with NewWsClient.
+type WsOption func(*WsClient)
+
+// WithWsReconnectPolicy sets the backoff between two connection attempts.
+// MaxAttempts is the number of consecutive failed connections after which the client gives up, 0 means never.
+func WithWsReconnectPolicy(policy RetryPolicy) WsOption {
+	return func(c *WsClient) {
+		c.reconnect = policy
+	}
+}
+
+// WithWsPingInterval sets the interval between two pings, 0 disables the keepalive.
+// A connection that does not answer within two intervals is considered dead and reconnected.
+func WithWsPingInterval(interval time.Duration) WsOption {
+	return func(c *WsClient) {
+		c.pingInterval = interval
+	}
+}
+
+// WithWsBufferSize sets the capacity of the message channels.
+func WithWsBufferSize(size int) WsOption {
+	return func(c *WsClient) {
+		c.bufferSize = size
+	}
+}
+
+// WsBufferPolicy selects what the client does with a message when its channel is full,
+// because the consumer is slower than the stream.
+type WsBufferPolicy int
+
+const (
+	// WsBlock waits for the consumer. A slow consumer stalls the connection, until Bitkub drops it.
+	WsBlock WsBufferPolicy = iota
+
+	// WsDropOldest drops the oldest message of the channel to make room for the new one.
+	WsDropOldest
+
+	// WsDropNewest drops the new message.
+	WsDropNewest
+
+	// WsConflate keeps only the latest ticker of each symbol until the consumer reads it,
+	// the other messages wait for the consumer like WsBlock.
+	WsConflate
+)
+
+// WithWsBufferPolicy sets what the client does when a message channel is full, WsBlock by default.
+// The dropped messages are counted by stream, see Dropped.
+func WithWsBufferPolicy(policy WsBufferPolicy) WsOption {
+	return func(c *WsClient) {
+		c.bufferPolicy = policy
+	}
+}
+
+// WithWsMaxStreams sets the number of streams of a connection, 0 only limits the length of the URL.
+// Larger subscriptions are sharded across several connections.
+func WithWsMaxStreams(n int) WsOption {
+	return func(c *WsClient) {
+		c.maxStreams = n
+	}
+}
+
+// WsClient is a websocket client that reconnects automatically.
+// Bitkub selects the streams with the URL of the connection, so the subscribed streams are sharded
+// across connections of at most WithWsMaxStreams streams, every order book stream on a connection of its own,
+// and a change of the subscribed streams reconnects the shards whose streams changed.
+// Messages are delivered on typed channels, which are closed when the client stops.
+type WsClient struct {
+	host         string
+	dialer       *websocket.Dialer
+	reconnect    RetryPolicy
+	pingInterval time.Duration
+	bufferSize   int
+	bufferPolicy WsBufferPolicy
+	maxStreams   int
+	maxConnAge   time.Duration                                                   // Renew the connection after this duration, 0 means never
+	onConnect    func(ctx context.Context, conn *websocket.Conn) ([]byte, error) // Called on every new connection before reading it, returns the messages it read
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	streams []string
+	changed chan struct{} // Signals a change of the subscribed streams
+
+	decoders  map[string]wsDecoder // Decoder of each stream kind (e.g. market.ticker)
+	conflate  *wsConflator         // Latest ticker of each symbol, with the WsConflate policy
+	droppedMu sync.Mutex
+	dropped   map[string]uint64 // Number of dropped messages of each stream
+	tickers   chan response.WsTicker
+	trades    chan response.WsTrade
+	books     chan response.WsOrderBook
+	orders    chan response.WsOrderUpdate
+	matches   chan response.WsMatch
+	errors    chan error
+}
+
+// NewWsClient creates a websocket client connected to the Bitkub websocket host.
+// The client runs until ctx is done or Close is called.
+func NewWsClient(ctx context.Context, opts ...WsOption) *WsClient {
+	return newWsClient(ctx, WS_HOST, opts...)
+}
+
+// NewWsClient creates a websocket client connected to the websocket host configured
+// on the SDK (see WithWSHost). It behaves like the package level NewWsClient.
+func (bksdk *SDK) NewWsClient(ctx context.Context, opts ...WsOption) *WsClient {
+	return newWsClient(ctx, bksdk.wsHost, opts...)
+}
+
+// newWsClient creates the client and starts its connection loop.
+func newWsClient(ctx context.Context, host string, opts ...WsOption) *WsClient {
+	c := &WsClient{
+		host:         host,
+		dialer:       websocket.DefaultDialer,
+		reconnect:    DefaultWsReconnectPolicy,
+		pingInterval: DefaultWsPingInterval,
+		bufferSize:   DefaultWsBufferSize,
+		maxStreams:   DefaultWsMaxStreams,
+		done:         make(chan struct{}),
+		changed:      make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	c.dropped = make(map[string]uint64)
+	c.tickers = make(chan response.WsTicker, c.bufferSize)
+	c.trades = make(chan response.WsTrade, c.bufferSize)
+	c.books = make(chan response.WsOrderBook, c.bufferSize)
+	c.orders = make(chan response.WsOrderUpdate, c.bufferSize)
+	c.matches = make(chan response.WsMatch, c.bufferSize)
+	c.errors = make(chan error, c.bufferSize)
+	c.decoders = map[string]wsDecoder{
+		wsTickerKind: func(ctx context.Context, message []byte) error {
+			return deliver(ctx, c, c.tickers, message, func(t response.WsTicker) string { return t.Stream })
+		},
+		wsTradeKind: func(ctx context.Context, message []byte) error {
+			return deliver(ctx, c, c.trades, message, func(t response.WsTrade) string { return t.Stream })
+		},
+		wsOrderBookKind: func(ctx context.Context, message []byte) error {
+			return deliver(ctx, c, c.books, message, func(b response.WsOrderBook) string { return OrderBookStream(b.PairingID) })
+		},
+		WsPrivateOrderKind: func(ctx context.Context, message []byte) error {
+			return deliver(ctx, c, c.orders, message, func(o response.WsOrderUpdate) string { return o.Stream })
+		},
+		WsPrivateMatchKind: func(ctx context.Context, message []byte) error {
+			return deliver(ctx, c, c.matches, message, func(m response.WsMatch) string { return m.Stream })
+		},
+	}
+
+	// With conflation the tickers wait in the conflator instead of the channel,
+	// so the consumer always reads the latest ticker of a symbol
+	if c.bufferPolicy == WsConflate {
+		c.tickers = make(chan response.WsTicker)
+		c.conflate = newWsConflator()
+		c.decoders[wsTickerKind] = c.conflateTicker
+		go c.pumpTickers()
+	}
+
+	go c.run()
+	return c
+}
+
+// Subscribe adds streams (e.g. market.ticker.thb_btc, see TickerStream) to the client.
+// The client reconnects the shards whose streams changed.
+func (c *WsClient) Subscribe(streams ...string) error {
+	if c.ctx.Err() != nil {
+		return bkerr.ErrWsClosed
+	}
+
+	c.mu.Lock()
+	added := false
+	for _, stream := range streams {
+		if stream != "" && !contains(c.streams, stream) {
+			c.streams = append(c.streams, stream)
+			added = true
+		}
+	}
+	c.mu.Unlock()
+
+	if added {
+		c.notifyChange()
+	}
+	return nil
+}
+
+// Unsubscribe removes streams from the client.
+// The client reconnects the shards whose streams changed.
+func (c *WsClient) Unsubscribe(streams ...string) error {
+	if c.ctx.Err() != nil {
+		return bkerr.ErrWsClosed
+	}
+
+	c.mu.Lock()
+	kept := c.streams[:0]
+	for _, stream := range c.streams {
+		if !contains(streams, stream) {
+			kept = append(kept, stream)
+		}
+	}
+	removed := len(kept) != len(c.streams)
+	c.streams = kept
+	c.mu.Unlock()
+
+	if removed {
+		c.notifyChange()
+	}
+	return nil
+}
+
+// Streams returns the subscribed streams.
+func (c *WsClient) Streams() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.streams...)
+}
+
+// Tickers returns the channel of the messages of the market.ticker streams.
+func (c *WsClient) Tickers() <-chan response.WsTicker {
+	return c.tickers
+}
+
+// Trades returns the channel of the messages of the market.trade streams.
+func (c *WsClient) Trades() <-chan response.WsTrade {
+	return c.trades
+}
+
+// OrderBooks returns the channel of the messages of the orderbook streams (see WS_ORDERBOOK_STREAM).
+func (c *WsClient) OrderBooks() <-chan response.WsOrderBook {
+	return c.books
+}
+
+// Orders returns the channel of the order updates of a private client (see NewPrivateWsClient).
+func (c *WsClient) Orders() <-chan response.WsOrderUpdate {
+	return c.orders
+}
+
+// Matches returns the channel of the matches of our orders of a private client (see NewPrivateWsClient).
+func (c *WsClient) Matches() <-chan response.WsMatch {
+	return c.matches
+}
+
+// Errors returns the channel of the *bkerr.WsError met by the client.
+// The client keeps running after an error. Errors are dropped when the channel is full.
+func (c *WsClient) Errors() <-chan error {
+	return c.errors
+}
+
+// Dropped returns the number of messages dropped by the buffer policy of each stream
+// (e.g. market.ticker.thb_btc or orderbook/1) since the client started.
+func (c *WsClient) Dropped() map[string]uint64 {
+	c.droppedMu.Lock()
+	defer c.droppedMu.Unlock()
+	dropped := make(map[string]uint64, len(c.dropped))
+	for stream, n := range c.dropped {
+		dropped[stream] = n
+	}
+	return dropped
+}
+
+// drop counts a dropped message of a stream.
+func (c *WsClient) drop(stream string) {
+	c.droppedMu.Lock()
+	c.dropped[stream]++
+	c.droppedMu.Unlock()
+}
+
+// Done returns a channel closed when the client has stopped.
+func (c *WsClient) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close closes the connection and stops the client, then the message channels are closed.
+func (c *WsClient) Close() error {
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+// notifyChange wakes up the connection loop without blocking.
+func (c *WsClient) notifyChange() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+// run keeps one connection per shard of the subscribed streams until the client is closed.
+// A stream stays in its shard while it is subscribed, so when the streams change only the shards
+// that lost or gained a stream are reconnected.
+func (c *WsClient) run() {
+	defer func() {
+		if c.conflate != nil {
+			<-c.conflate.done
+		}
+		close(c.tickers)
+		close(c.trades)
+		close(c.books)
+		close(c.orders)
+		close(c.matches)
+		close(c.errors)
+		close(c.done)
+	}()
+
+	// Each shard keeps its streams while they are subscribed, so a change only reconnects the shards it touches
+	type shard struct {
+		streams []string
+		cancel  context.CancelFunc
+		done    chan struct{}
+	}
+	var running []*shard
+	stop := func(sh *shard) {
+		sh.cancel()
+		<-sh.done
+	}
+	defer func() {
+		for _, sh := range running {
+			stop(sh)
+		}
+	}()
+
+	for {
+		// Take the pending change into account, a change made after the snapshot signals again
+		select {
+		case <-c.changed:
+		default:
+		}
+
+		// Keep the streams of every shard that are still subscribed
+		streams := c.Streams()
+		assigned := make(map[string]bool, len(streams))
+		for _, stream := range streams {
+			assigned[stream] = false
+		}
+		plans := make([][]string, len(running))
+		for i, sh := range running {
+			for _, stream := range sh.streams {
+				if done, ok := assigned[stream]; ok && !done {
+					plans[i] = append(plans[i], stream)
+					assigned[stream] = true
+				}
+			}
+		}
+
+		// Add the new streams to the first shard with room, or to a new shard
+		for _, stream := range streams {
+			if assigned[stream] {
+				continue
+			}
+			placed := false
+			for i := range plans {
+				if c.fits(plans[i], stream) {
+					plans[i] = append(plans[i], stream)
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				plans = append(plans, []string{stream})
+			}
+		}
+
+		// Reconnect the shards whose streams changed, start the new ones and stop the empty ones
+		var next []*shard
+		for i, plan := range plans {
+			if i < len(running) {
+				if equalStreams(running[i].streams, plan) {
+					next = append(next, running[i])
+					continue
+				}
+				stop(running[i])
+			}
+			if len(plan) == 0 {
+				continue
+			}
+
+			ctx, cancel := context.WithCancel(c.ctx)
+			sh := &shard{streams: plan, cancel: cancel, done: make(chan struct{})}
+			next = append(next, sh)
+			go func() {
+				defer close(sh.done)
+				c.runShard(ctx, sh.streams)
+			}()
+		}
+		running = next
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.changed:
+		}
+	}
+}
+
+// fits reports whether a stream can be added to the streams of a shard without exceeding
+// maxStreams streams or wsMaxURLLength characters. An order book stream has a shard of its own.
+func (c *WsClient) fits(streams []string, stream string) bool {
+	if c.maxStreams > 0 && len(streams) >= c.maxStreams {
+		return false
+	}
+	if len(streams) == 0 {
+		return true
+	}
+	if ownShard(stream) || ownShard(streams[0]) {
+		return false
+	}
+	length := len(c.host) + len(strings.Join(streams, ",")) + 1 + len(stream)
+	return length <= wsMaxURLLength
+}
+
+// ownShard reports whether a stream needs a connection of its own. Bitkub only joins the market streams
+// (market.ticker and market.trade) in the URL of a connection, an order book stream is a path of its own.
+func ownShard(stream string) bool {
+	return strings.HasPrefix(stream, strings.TrimSuffix(WS_ORDERBOOK_STREAM, "%d"))
+}
+
+// equalStreams reports whether two shards have the same streams in the same order.
+func equalStreams(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// runShard connects with the streams, serves the connection and reconnects until ctx is done.
+func (c *WsClient) runShard(ctx context.Context, streams []string) {
+	failures := 0
+	for {
+		// Connect with the streams of the shard and serve the connection
+		op := "dial"
+		conn, _, err := c.dialer.DialContext(ctx, c.host+strings.Join(streams, ","), nil)
+		var pending []byte
+		if err == nil && c.onConnect != nil {
+			op = "auth"
+			if pending, err = c.onConnect(ctx, conn); err != nil {
+				conn.Close()
+			}
+		}
+		if err == nil {
+			failures = 0
+			op = "read"
+			c.dispatch(ctx, pending)
+			err = c.serve(ctx, conn)
+			if err == errRenew {
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Report the failure and wait for the backoff before reconnecting
+		c.emit(&bkerr.WsError{Op: op, Err: err})
+		failures++
+		if c.reconnect.MaxAttempts > 0 && failures >= c.reconnect.MaxAttempts {
+			c.cancel()
+			return
+		}
+		if c.reconnect.wait(ctx, failures) != nil {
+			return
+		}
+	}
+}
+
+// serve reads the connection until it fails, ctx is done or the connection reaches its maximum age.
+func (c *WsClient) serve(ctx context.Context, conn *websocket.Conn) error {
+	defer conn.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// Keep the connection alive, and close it to unblock the reader when ctx is done
+	// or the connection must be renewed
+	var renew atomic.Bool
+	go func() {
+		var ping <-chan time.Time
+		if c.pingInterval > 0 {
+			ticker := time.NewTicker(c.pingInterval)
+			defer ticker.Stop()
+			ping = ticker.C
+		}
+
+		var expire <-chan time.Time
+		if c.maxConnAge > 0 {
+			timer := time.NewTimer(c.maxConnAge)
+			defer timer.Stop()
+			expire = timer.C
+		}
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteWait))
+				conn.Close()
+				return
+			case <-expire:
+				renew.Store(true)
+				conn.Close()
+				return
+			case <-ping:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	// A connection is dead when neither a message nor a pong arrives within two ping intervals
+	extendDeadline := func() error {
+		if c.pingInterval <= 0 {
+			return nil
+		}
+		return conn.SetReadDeadline(time.Now().Add(2 * c.pingInterval))
+	}
+	conn.SetPongHandler(func(string) error {
+		return extendDeadline()
+	})
+
+	for {
+		if err := extendDeadline(); err != nil {
+			return err
+		}
+
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if renew.Load() {
+				return errRenew
+			}
+			return err
+		}
+		c.dispatch(ctx, message)
+	}
+}
+
+// dispatch splits a frame into its messages and routes each one by its stream to the decoder of the stream kind.
+// Bitkub sends several newline-delimited JSON objects in a single frame.
+func (c *WsClient) dispatch(ctx context.Context, frame []byte) {
+	for _, message := range bytes.Split(frame, []byte("\n")) {
+		message = bytes.TrimSpace(message)
+		if len(message) == 0 {
+			continue
+		}
+
+		if err := c.decode(ctx, message); err != nil {
+			c.emit(&bkerr.WsError{Op: "decode", Message: string(message), Err: err})
+		}
+	}
+}
+
+// decode routes a single message to the decoder of its stream kind.
+func (c *WsClient) decode(ctx context.Context, message []byte) error {
+	var envelope struct {
+		Stream    string `json:"stream"`
+		PairingID int    `json:"pairing_id"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return err
+	}
+
+	// A message of the order book stream has a pairing id instead of a stream
+	if envelope.Stream == "" && envelope.PairingID != 0 {
+		envelope.Stream = wsOrderBookKind
+	}
+
+	// A message without a stream is a control message (e.g. the answer to the authentication)
+	if envelope.Stream == "" {
+		return nil
+	}
+
+	// Look up the decoder of the stream, then of its kind
+	decoder, ok := c.decoders[envelope.Stream]
+	if !ok {
+		kind, _ := response.ParseStream(envelope.Stream)
+		decoder, ok = c.decoders[kind]
+	}
+	if !ok {
+		return fmt.Errorf("no decoder for stream %q", envelope.Stream)
+	}
+	return decoder(ctx, message)
+}
+
+// deliver decodes a message into a T and sends it to ch according to the buffer policy.
+// stream returns the stream of a message, to count the dropped messages.
+// With WsBlock the send waits for the consumer until ctx, the context of the shard, is done,
+// so a shard being stopped is never held by a full channel.
+func deliver[T any](ctx context.Context, c *WsClient, ch chan T, message []byte, stream func(T) string) error {
+	var v T
+	if err := json.Unmarshal(message, &v); err != nil {
+		return err
+	}
+
+	switch c.bufferPolicy {
+	case WsDropNewest:
+		select {
+		case ch <- v:
+		default:
+			c.drop(stream(v))
+		}
+
+	case WsDropOldest:
+		// Another shard may fill the channel again, so drop until the message fits
+		for {
+			select {
+			case ch <- v:
+				return nil
+			default:
+			}
+			select {
+			case old := <-ch:
+				c.drop(stream(old))
+			default:
+			}
+		}
+
+	default:
+		select {
+		case ch <- v:
+		case <-ctx.Done():
+		}
+	}
+	return nil
+}
+
+// wsConflator holds the latest ticker of each stream until it is sent to the consumer.
+type wsConflator struct {
+	mu      sync.Mutex
+	latest  map[string]response.WsTicker
+	order   []string      // Streams waiting to be sent, in the order of their first pending ticker
+	pending chan struct{} // Signals a new stream waiting to be sent
+	done    chan struct{} // Closed when the pump stops
+}
+
+func newWsConflator() *wsConflator {
+	return &wsConflator{
+		latest:  make(map[string]response.WsTicker),
+		pending: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// conflateTicker decodes a ticker and replaces the pending ticker of its stream.
+func (c *WsClient) conflateTicker(_ context.Context, message []byte) error {
+	var ticker response.WsTicker
+	if err := json.Unmarshal(message, &ticker); err != nil {
+		return err
+	}
+
+	q := c.conflate
+	q.mu.Lock()
+	_, replaced := q.latest[ticker.Stream]
+	q.latest[ticker.Stream] = ticker
+	if !replaced {
+		q.order = append(q.order, ticker.Stream)
+	}
+	q.mu.Unlock()
+
+	if replaced {
+		c.drop(ticker.Stream)
+		return nil
+	}
+	select {
+	case q.pending <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// pumpTickers sends the pending tickers to the tickers channel until the client stops.
+func (c *WsClient) pumpTickers() {
+	q := c.conflate
+	defer close(q.done)
+
+	for {
+		// Take the ticker of the oldest waiting stream
+		q.mu.Lock()
+		if len(q.order) == 0 {
+			q.mu.Unlock()
+			select {
+			case <-q.pending:
+				continue
+			case <-c.ctx.Done():
+				return
+			}
+		}
+		ticker := q.latest[q.order[0]]
+		delete(q.latest, q.order[0])
+		q.order = q.order[1:]
+		q.mu.Unlock()
+
+		select {
+		case c.tickers <- ticker:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// emit sends an error to the error channel, or drops it when the channel is full.
+func (c *WsClient) emit(err error) {
+	select {
+	case c.errors <- err:
+	default:
+	}
+}
+
+// contains reports whether list holds s.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
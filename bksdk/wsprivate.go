@@ -0,0 +1,140 @@
+package bksdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+)
+
+// Private websocket protocol.
+// The private connection is opened on WS_PRIVATE_STREAM, authenticated with a token from WsToken,
+// then delivers messages whose stream is one of the private kinds below.
+//
+// Experimental: Bitkub does not document a private websocket stream. These names, the authentication
+// message and its answer ({"event":"auth","status":"ok"}) are assumptions and may change without notice.
+const (
+	WS_PRIVATE_STREAM  = "private"
+	WsPrivateOrderKind = "private.order" // Stream of the updates of our orders
+	WsPrivateMatchKind = "private.match" // Stream of the matches of our orders
+	wsAuthEvent        = "auth"
+	wsAuthOK           = "ok"
+)
+
+// DefaultWsTokenTTL is the lifetime of a websocket token. The private connection is renewed
+// with a new token a minute before the token expires.
+const DefaultWsTokenTTL = time.Hour
+
+// wsTokenRenewMargin is how long before the expiry of the token the connection is renewed.
+const wsTokenRenewMargin = time.Minute
+
+// wsAuthWait is the time allowed for the answer to the authentication.
+const wsAuthWait = 10 * time.Second
+
+// wsAuthMessage is the first message sent on a private connection.
+type wsAuthMessage struct {
+	Event string `json:"event"`
+	Token string `json:"token"`
+}
+
+// wsAuthReply is the answer of the server to the authentication.
+type wsAuthReply struct {
+	Event   string `json:"event"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// WithWsTokenTTL sets the lifetime of the websocket token of a private client.
+// The connection is renewed with a new token before the token expires.
+func WithWsTokenTTL(ttl time.Duration) WsOption {
+	return func(c *WsClient) {
+		c.maxConnAge = ttl
+		if ttl > 2*wsTokenRenewMargin {
+			c.maxConnAge = ttl - wsTokenRenewMargin
+		}
+	}
+}
+
+// NewPrivateWsClient creates a websocket client that delivers the updates and the matches of our orders
+// on its Orders and Matches channels. Every connection is authenticated with a new token from WsToken,
+// and is renewed before the token expires (see WithWsTokenTTL).
+// A failed authentication, a rejected token included, is reported as a *bkerr.WsError with the auth operation
+// wrapping bkerr.ErrWsAuth, then the client reconnects.
+//
+// Experimental: the private stream is not documented by Bitkub, see WS_PRIVATE_STREAM.
+// It is left out of SDKEndpoints until it can be built against a protocol published by Bitkub.
+func (bksdk *SDK) NewPrivateWsClient(ctx context.Context, opts ...WsOption) *WsClient {
+	opts = append([]WsOption{
+		WithWsTokenTTL(DefaultWsTokenTTL),
+		func(c *WsClient) {
+			c.onConnect = bksdk.wsAuth
+		},
+	}, opts...)
+
+	c := newWsClient(ctx, bksdk.wsHost, opts...)
+	_ = c.Subscribe(WS_PRIVATE_STREAM)
+	return c
+}
+
+// wsAuth authenticates a private connection with a new websocket token and waits for the answer of the server.
+// The messages received in the same frames as the answer are returned, to be delivered once the connection is served.
+func (bksdk *SDK) wsAuth(ctx context.Context, conn *websocket.Conn) ([]byte, error) {
+	// Get a new token
+	token, err := bksdk.WsTokenCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the authentication message
+	if err := conn.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+		return nil, err
+	}
+	if err := conn.WriteJSON(wsAuthMessage{Event: wsAuthEvent, Token: token}); err != nil {
+		return nil, err
+	}
+
+	// Read until the answer, the deadline is reset for the reader of the connection
+	if err := conn.SetReadDeadline(time.Now().Add(wsAuthWait)); err != nil {
+		return nil, err
+	}
+	defer conn.SetReadDeadline(time.Time{})
+
+	// Close the connection to unblock the reader when ctx is done
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	var pending [][]byte
+	for {
+		_, frame, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		// The answer may share its frame with the first messages of the streams
+		authenticated := false
+		for _, message := range bytes.Split(frame, []byte("\n")) {
+			var reply wsAuthReply
+			if authenticated || json.Unmarshal(message, &reply) != nil || reply.Event != wsAuthEvent {
+				pending = append(pending, message)
+				continue
+			}
+			if reply.Status != wsAuthOK {
+				return nil, fmt.Errorf("%w: %s", bkerr.ErrWsAuth, strings.TrimSpace(reply.Status+" "+reply.Message))
+			}
+			authenticated = true
+		}
+		if authenticated {
+			return bytes.Join(pending, []byte("\n")), nil
+		}
+	}
+}
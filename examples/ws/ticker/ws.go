@@ -2,82 +2,51 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 
 	"github.com/naruebaet/bitkub-sdk/bksdk"
-	"github.com/naruebaet/bitkub-sdk/bksdk/response"
 )
 
 // main is the entry point of the program.
 func main() {
-	// Create a channel to receive signals for interrupt and termination.
-	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
-
-	// Create a context with a timeout of 1 minute.
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create a context cancelled on interrupt and termination signals.
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
 
 	// Create a new instance of the SDK with the provided API credentials.
 	sdk := bksdk.New("xxx", "xxx")
 
-	// Get the symbols from the SDK.
-	symbols, err := sdk.GetSymbols()
+	// Create the ticker stream of every symbol listed by GetSymbols.
+	streams, err := sdk.TickerStreams(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	// Create the streamLine string from the symbols.
-	var streams []string
-	for _, sym := range symbols {
-		streams = append(streams, fmt.Sprintf(bksdk.WS_TICKER_STREAM, sym.Symbol))
-	}
-	streamLine := strings.Join(streams, ",")
-
 	fmt.Println("ws starting...")
 
-	// Create a channel for reading the WebSocket messages.
-	reader := make(chan string)
-	defer close(reader)
+	// Create a websocket client, it stops when the context is done.
+	client := sdk.NewWsClient(ctx)
+	defer client.Close()
 
-	// Create a WebSocket connection with the streamLine and reader.
-	bksdk.CreateWsConnection(streamLine, reader, ctx)
+	if err := client.Subscribe(streams...); err != nil {
+		panic(err)
+	}
 
-	// Read messages from the reader channel until the context is done.
+	// Read the tickers until the client stops.
 	for {
 		select {
-		case sig := <-signals:
-			fmt.Println("-------------------")
-			fmt.Println("Signal received")
-			fmt.Println(sig)
-			cancel()
-			fmt.Println("-------------------")
-		default:
-			raw := <-reader
-			if raw == "Connection closed" {
-				fmt.Println(raw)
-				return
-			}
-
-			// Parse the message to ws ticker type and print the ticker and last price.
-			messages := strings.Split(raw, "\n")
-			for _, message := range messages {
-				var ticker response.WsTicker
-				err := json.Unmarshal([]byte(message), &ticker)
-				if err != nil {
-					fmt.Println("---------ERR----------")
-					fmt.Println(err)
-					fmt.Println(raw)
-					fmt.Println("---------ERR----------")
-					continue
-				}
-
-				fmt.Printf("Ticker: %s, Last: %f\n", ticker.Stream[18:], ticker.Last)
-			}
+		case ticker := <-client.Tickers():
+			fmt.Printf("Ticker: %s, Last: %s\n", ticker.Symbol, ticker.Last)
+		case err := <-client.Errors():
+			fmt.Println("---------ERR----------")
+			fmt.Println(err)
+			fmt.Println("---------ERR----------")
+		case <-client.Done():
+			fmt.Println("Connection closed")
+			return
 		}
 	}
 }
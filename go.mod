@@ -5,7 +5,6 @@ go 1.21.5
 require (
 	github.com/go-playground/validator/v10 v10.16.0
 	github.com/joho/godotenv v1.5.1
-	github.com/parnurzeal/gorequest v0.2.16
 )
 
 require (
@@ -22,11 +21,7 @@ require (
 )
 
 require (
-	github.com/elazarl/goproxy v0.0.0-20231117061959-7cc037d33fb5 // indirect
 	github.com/gorilla/websocket v1.5.1
-	github.com/pkg/errors v0.9.1 // indirect
-	github.com/smartystreets/goconvey v1.8.1 // indirect
 	github.com/stretchr/testify v1.8.4
 	golang.org/x/net v0.19.0 // indirect
-	moul.io/http2curl v1.0.0 // indirect
 )
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAPIError checks that an error code from the API is returned as a typed error.
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			w.Write([]byte(`1702396382000`))
+			return
+		}
+		w.Write([]byte(`{"error":18}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	_, err = sdk.PlaceBid("btc_thb", decimal.FromInt(1000), decimal.Zero, "market", "")
+
+	// Compare with the sentinel error.
+	assert.ErrorIs(t, err, bkerr.ErrInsufficientBalance)
+	assert.NotErrorIs(t, err, bkerr.ErrInvalidSymbol)
+
+	// Read the details of the error.
+	var apiErr *bkerr.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, bkerr.InsufficientBalance, apiErr.Code)
+	assert.Equal(t, "Insufficient balance", apiErr.Message)
+	assert.Equal(t, http.StatusOK, apiErr.HTTPStatus)
+	assert.Equal(t, api.MarketPlaceBidV3, apiErr.Endpoint)
+	assert.Equal(t, `{"error":18}`, apiErr.RawBody)
+}
+
+// TestErrorClassification checks the classification helpers.
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		retryable   bool
+		auth        bool
+		maintenance bool
+	}{
+		{name: "server error", err: bkerr.NewAPIError(bkerr.ServerError, 200, "", ""), retryable: true},
+		{name: "invalid signature", err: bkerr.NewAPIError(bkerr.MissingInvalidSignature, 200, "", ""), auth: true},
+		{name: "ip not allowed", err: bkerr.NewAPIError(bkerr.IPNotAllowed, 200, "", ""), auth: true},
+		{name: "cancel only mode", err: bkerr.NewAPIError(bkerr.CancelOnlyMode, 200, "", ""), maintenance: true},
+		{name: "wrapped withdrawal maintenance", err: fmt.Errorf("withdraw: %w", bkerr.ErrWithdrawalIsUnderMaintenance), maintenance: true},
+		{name: "insufficient balance", err: bkerr.ErrInsufficientBalance},
+		{name: "not an api error", err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retryable, bkerr.IsRetryable(tt.err))
+			assert.Equal(t, tt.auth, bkerr.IsAuthError(tt.err))
+			assert.Equal(t, tt.maintenance, bkerr.IsMaintenance(tt.err))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/candles"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// wsTrade returns a trade of the thb_btc stream at a time.
+func wsTrade(at time.Time, rate, amount string) response.WsTrade {
+	return response.WsTrade{Symbol: "thb_btc", Stream: "market.trade.thb_btc", Ts: int(at.Unix()), Rat: dec(rate), Amt: dec(amount)}
+}
+
+// TestCandles checks the OHLCV, the late trades and the empty intervals of the candle builder.
+func TestCandles(t *testing.T) {
+	var closed []candles.Candle
+	builder, err := candles.New("THB_BTC", []string{"1", "5"}, candles.WithLateness(10*time.Second),
+		candles.WithCandleHandler(func(c candles.Candle) { closed = append(closed, c) }))
+	assert.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	builder.Add(wsTrade(start.Add(5*time.Second), "100", "1"))
+	builder.Add(wsTrade(start.Add(20*time.Second), "120", "2"))
+	builder.Add(wsTrade(start.Add(40*time.Second), "90", "1"))
+
+	current, ok := builder.Current("1")
+	assert.True(t, ok)
+	assert.Equal(t, candles.Candle{Symbol: "thb_btc", Resolution: "1", Time: start,
+		Open: dec("100"), High: dec("120"), Low: dec("90"), Close: dec("90"), Volume: dec("4")}, current)
+
+	// A trade of the next interval does not close the candle before the grace period
+	builder.Add(wsTrade(start.Add(65*time.Second), "110", "1"))
+	assert.Empty(t, closed)
+
+	// A late trade within the grace period is still counted, its time keeps the open and close in order
+	builder.Add(wsTrade(start.Add(2*time.Second), "95", "1"))
+	builder.Add(wsTrade(start.Add(75*time.Second), "115", "1"))
+	assert.Len(t, closed, 1)
+	assert.Equal(t, candles.Candle{Symbol: "thb_btc", Resolution: "1", Time: start,
+		Open: dec("95"), High: dec("120"), Low: dec("90"), Close: dec("90"), Volume: dec("5")}, closed[0])
+
+	// A trade after the 1 minute candle closed is dropped from it, the 5 minute candle is still open
+	builder.Add(wsTrade(start.Add(30*time.Second), "200", "1"))
+	assert.Equal(t, 1, builder.Late())
+
+	// Intervals without trades are flat at the previous close
+	builder.Advance(start.Add(4*time.Minute + 15*time.Second))
+	assert.Len(t, closed, 4)
+	assert.Equal(t, candles.Candle{Symbol: "thb_btc", Resolution: "1", Time: start.Add(time.Minute),
+		Open: dec("110"), High: dec("115"), Low: dec("110"), Close: dec("115"), Volume: dec("2")}, closed[1])
+	for i, candle := range closed[2:] {
+		assert.Equal(t, candles.Candle{Symbol: "thb_btc", Resolution: "1", Time: start.Add(time.Duration(i+2) * time.Minute),
+			Open: dec("115"), High: dec("115"), Low: dec("115"), Close: dec("115")}, candle)
+	}
+
+	// The 5 minute candle holds every trade of its interval
+	builder.Advance(start.Add(5*time.Minute + 10*time.Second))
+	five := closed[len(closed)-1]
+	assert.Equal(t, candles.Candle{Symbol: "thb_btc", Resolution: "5", Time: start,
+		Open: dec("95"), High: dec("200"), Low: dec("90"), Close: dec("115"), Volume: dec("8")}, five)
+
+	// Trades of another symbol are ignored
+	builder.Add(response.WsTrade{Stream: "market.trade.thb_eth", Ts: int(start.Add(6 * time.Minute).Unix()), Rat: dec("1"), Amt: dec("1")})
+	_, ok = builder.Current("5")
+	assert.False(t, ok)
+
+	_, err = candles.New("THB_BTC", []string{"2"})
+	assert.Error(t, err)
+}
+
+// TestCandlesSeed checks that the candle in progress is seeded from GetHistory.
+func TestCandlesSeed(t *testing.T) {
+	now := time.Now().UTC()
+	current := now.Truncate(time.Minute)
+	previous := current.Add(-time.Minute)
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, api.TradingviewHistory, r.URL.Path)
+		assert.Equal(t, "BTC_THB", r.URL.Query().Get("sym"))
+		fmt.Fprintf(w, `{"s":"ok","t":[%d,%d],"o":[100,105],"h":[110,108],"l":[95,104],"c":[105,106],"v":[3,1]}`,
+			previous.Unix(), current.Unix())
+	}))
+	defer apiSrv.Close()
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(apiSrv.URL))
+	assert.NoError(t, err)
+
+	var closed []candles.Candle
+	builder, err := candles.New("thb_btc", []string{"1"}, candles.WithLateness(0),
+		candles.WithCandleHandler(func(c candles.Candle) { closed = append(closed, c) }))
+	assert.NoError(t, err)
+	assert.NoError(t, builder.Seed(context.Background(), sdk))
+
+	// The trades of the stream update the seeded candle
+	builder.Add(wsTrade(current.Add(time.Second), "109", "2"))
+	candle, ok := builder.Current("1")
+	assert.True(t, ok)
+	assert.Equal(t, candles.Candle{Symbol: "thb_btc", Resolution: "1", Time: current,
+		Open: dec("105"), High: dec("109"), Low: dec("104"), Close: dec("109"), Volume: dec("3")}, candle)
+
+	// The seeded candle is closed after its interval, followed by a flat candle
+	builder.Advance(current.Add(2 * time.Minute))
+	assert.Len(t, closed, 2)
+	assert.Equal(t, current, closed[0].Time)
+	assert.Equal(t, candles.Candle{Symbol: "thb_btc", Resolution: "1", Time: current.Add(time.Minute),
+		Open: dec("109"), High: dec("109"), Low: dec("109"), Close: dec("109")}, closed[1])
+}
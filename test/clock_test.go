@@ -0,0 +1,160 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClockSync checks that the server time is fetched once and the offset is used to sign the following calls.
+func TestClockSync(t *testing.T) {
+	// The server clock is one hour ahead of the local clock
+	offset := time.Hour
+
+	var serverTimeCalls int32
+	var timestamps []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api.ServertimeV3:
+			atomic.AddInt32(&serverTimeCalls, 1)
+			w.Write([]byte(strconv.FormatInt(time.Now().Add(offset).UnixMilli(), 10)))
+		default:
+			timestamps = append(timestamps, r.Header.Get("X-BTK-TIMESTAMP"))
+			w.Write([]byte(`{"error":0,"result":{}}`))
+		}
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := sdk.Balances()
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&serverTimeCalls))
+	assert.Len(t, timestamps, 3)
+	for _, ts := range timestamps {
+		ms, err := strconv.ParseInt(ts, 10, 64)
+		assert.NoError(t, err)
+		assert.InDelta(t, time.Now().Add(offset).UnixMilli(), ms, float64(time.Second.Milliseconds()))
+	}
+}
+
+// TestClockSyncInterval checks that the offset is measured again after the interval.
+func TestClockSyncInterval(t *testing.T) {
+	var serverTimeCalls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			atomic.AddInt32(&serverTimeCalls, 1)
+			w.Write([]byte(strconv.FormatInt(time.Now().UnixMilli(), 10)))
+			return
+		}
+		w.Write([]byte(`{"error":0,"result":{}}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret",
+		bksdk.WithBaseURL(srv.URL),
+		bksdk.WithClockSyncInterval(30*time.Millisecond),
+	)
+	assert.NoError(t, err)
+
+	_, err = sdk.Balances()
+	assert.NoError(t, err)
+	time.Sleep(40 * time.Millisecond)
+	_, err = sdk.Balances()
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&serverTimeCalls))
+}
+
+// TestClockSyncError checks that a failed sync is returned instead of signing with an empty timestamp.
+func TestClockSyncError(t *testing.T) {
+	var placed int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		atomic.AddInt32(&placed, 1)
+		w.Write([]byte(`{"error":0,"result":{}}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret",
+		bksdk.WithBaseURL(srv.URL),
+		bksdk.WithRetryPolicy(bksdk.NoRetry),
+	)
+	assert.NoError(t, err)
+
+	_, err = sdk.PlaceBid("btc_thb", decimal.FromInt(1000), decimal.Zero, "market", "")
+	assert.ErrorIs(t, err, bkerr.ErrClockSync)
+
+	var httpErr *bkerr.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&placed))
+}
+
+// TestClockSyncSlow checks that a slow sync does not hold the other signed calls past their own context,
+// and that the calls waiting for it share its result.
+func TestClockSyncSlow(t *testing.T) {
+	release := make(chan struct{})
+	var serverTimeCalls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			atomic.AddInt32(&serverTimeCalls, 1)
+			<-release
+			w.Write([]byte(strconv.FormatInt(time.Now().UnixMilli(), 10)))
+			return
+		}
+		w.Write([]byte(`{"error":0,"result":{}}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	// The first call starts the sync without a deadline
+	done := make(chan error, 1)
+	go func() {
+		_, err := sdk.Balances()
+		done <- err
+	}()
+	for atomic.LoadInt32(&serverTimeCalls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A call with a deadline gives up waiting for it
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err = sdk.BalancesCtx(ctx)
+	assert.ErrorIs(t, err, bkerr.ErrClockSync)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+
+	// A call without a deadline waits for the sync in flight instead of starting another one
+	waiting := make(chan error, 1)
+	go func() {
+		_, err := sdk.Balances()
+		waiting <- err
+	}()
+
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-waiting)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&serverTimeCalls))
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConcurrentCalls checks that one SDK can be shared by many goroutines.
+// Run it with `go test -race` to detect data races.
+func TestConcurrentCalls(t *testing.T) {
+	const secret = "secret"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api.ServertimeV3:
+			w.Write([]byte(strconv.FormatInt(time.Now().UnixMilli(), 10)))
+		case api.MarketTicker:
+			// Echo the symbol, so the caller can check it received its own answer
+			sym := r.URL.Query().Get("sym")
+			fmt.Fprintf(w, `{%q:{"id":1,"last":100}}`, sym)
+		case api.MarketPlaceBidV3:
+			body, _ := io.ReadAll(r.Body)
+
+			// Check the signature against the body that was actually sent
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write([]byte(r.Header.Get("X-BTK-TIMESTAMP") + r.Method + r.URL.Path + string(body)))
+			if hex.EncodeToString(mac.Sum(nil)) != r.Header.Get("X-BTK-SIGN") {
+				w.Write([]byte(`{"error":6}`))
+				return
+			}
+
+			var req struct {
+				ClientID string `json:"client_id"`
+			}
+			json.Unmarshal(body, &req)
+			fmt.Fprintf(w, `{"error":0,"result":{"ci":%q}}`, req.ClientID)
+		}
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", secret, bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			sym := fmt.Sprintf("thb_sym%d", i)
+			got, err := sdk.GetTicker(sym)
+			assert.NoError(t, err)
+			assert.Contains(t, got, sym)
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+			clientID := fmt.Sprintf("bot-%d", i)
+			got, err := sdk.PlaceBid("btc_thb", decimal.FromInt(1000), decimal.FromInt(15000), "limit", clientID)
+			assert.NoError(t, err)
+			assert.Equal(t, clientID, got.Ci)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCtxDeadline checks that a context deadline aborts an in-flight call.
+func TestCtxDeadline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = sdk.GetTickerCtx(ctx, "btc_thb")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+// TestCtxCancelSecure checks that a cancelled context stops a secure call
+// before the server time is fetched.
+func TestCtxCancelSecure(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"error":0,"result":[]}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = sdk.MyOpenOrderCtx(ctx, "btc_thb")
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, calls)
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/request"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDecimalParse checks the parsing and the formatting of decimals.
+func TestDecimalParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "1000", want: "1000"},
+		{in: "1000.00", want: "1000"},
+		{in: "0.00012000", want: "0.00012"},
+		{in: "-12.50", want: "-12.5"},
+		{in: "+3", want: "3"},
+		{in: ".5", want: "0.5"},
+		{in: "1e-8", want: "0.00000001"},
+		{in: "1.5E+3", want: "1500"},
+		{in: "0.000", want: "0"},
+		{in: "123456789012345678901234567890.123456789", want: "123456789012345678901234567890.123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := decimal.Parse(tt.in)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, d.String())
+		})
+	}
+
+	for _, in := range []string{"", "abc", "1.2.3", "1e", "--1", "."} {
+		_, err := decimal.Parse(in)
+		assert.ErrorIs(t, err, decimal.ErrInvalid, in)
+	}
+}
+
+// TestDecimalArithmetic checks that the operations are exact where floats are not.
+func TestDecimalArithmetic(t *testing.T) {
+	a := decimal.MustParse("0.1")
+	b := decimal.MustParse("0.2")
+
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.True(t, a.Add(b).Equal(decimal.MustParse("0.30")))
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.True(t, decimal.Zero.IsZero())
+	assert.Equal(t, "0.1", decimal.FromFloat(0.1).String())
+	assert.Equal(t, "123.45", decimal.New(12345, 2).String())
+
+	// Division and rounding
+	assert.Equal(t, "0.33333333", decimal.FromInt(1).Div(decimal.FromInt(3), 8).String())
+	assert.Equal(t, "0.66666667", decimal.FromInt(2).Div(decimal.FromInt(3), 8).String())
+	assert.Equal(t, "1.24", decimal.MustParse("1.235").Round(2).String())
+	assert.Equal(t, "-1.24", decimal.MustParse("-1.235").Round(2).String())
+	assert.Equal(t, "1.23", decimal.MustParse("1.239").Truncate(2).String())
+}
+
+// TestDecimalJSON checks that decimals decode from numbers and strings and encode without trailing zeros.
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Number  decimal.Decimal `json:"number"`
+		String  decimal.Decimal `json:"string"`
+		Exp     decimal.Decimal `json:"exp"`
+		Empty   decimal.Decimal `json:"empty"`
+		Null    decimal.Decimal `json:"null"`
+		Missing decimal.Decimal `json:"missing"`
+	}
+	err := json.Unmarshal([]byte(`{"number":1000.50,"string":"0.00012000","exp":1e-08,"empty":"","null":null}`), &v)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000.5", v.Number.String())
+	assert.Equal(t, "0.00012", v.String.String())
+	assert.Equal(t, "0.00000001", v.Exp.String())
+	assert.True(t, v.Empty.IsZero())
+	assert.True(t, v.Null.IsZero())
+	assert.True(t, v.Missing.IsZero())
+
+	var d decimal.Decimal
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &d))
+
+	// Bitkub rejects trailing zeros in amounts and rates
+	body, err := json.Marshal(request.PlaceBid{
+		Symbol: "btc_thb",
+		Type:   "limit",
+		Amount: decimal.MustParse("1000.00"),
+		Rate:   decimal.MustParse("0.00001000"),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"sym":"btc_thb","typ":"limit","client_id":"","amt":1000,"rat":0.00001}`, string(body))
+}
+
+// TestDecimalResponse checks that the money fields of a response keep every digit.
+func TestDecimalResponse(t *testing.T) {
+	var order response.MyOrderHistoryResult
+	err := json.Unmarshal([]byte(`{"rate":"1504031.12","fee":"0.0000025","credit":"0","amount":"0.00012345678"}`), &order)
+	assert.NoError(t, err)
+	assert.Equal(t, "1504031.12", order.Rate.String())
+	assert.Equal(t, "0.0000025", order.Fee.String())
+	assert.Equal(t, "0.00012345678", order.Amount.String())
+
+	var info response.OrderInfoResult
+	err = json.Unmarshal([]byte(`{"amount":"100","rate":1250000.25,"fee":0.25,"credit":0.1,"filled":0.5,"total":100,"remaining":49.75}`), &info)
+	assert.NoError(t, err)
+	assert.Equal(t, "1250000.25", info.Rate.String())
+	assert.Equal(t, "0.25", info.Fee.String())
+	assert.Equal(t, "49.75", info.Remaining.String())
+}
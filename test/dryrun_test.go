@@ -0,0 +1,139 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// dryRunServer answers the order endpoints and records the path and payload of every order.
+func dryRunServer(t *testing.T) (*httptest.Server, func() (string, map[string]any)) {
+	var mu sync.Mutex
+	var path string
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			w.Write([]byte(`1702396382000`))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		path = r.URL.Path
+		payload = nil
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		mu.Unlock()
+
+		// The test endpoints answer with a numeric id
+		id := `"1"`
+		if r.URL.Path == api.MarketPlaceBidTest || r.URL.Path == api.MarketPlaceAskTest {
+			id = `0`
+		}
+		w.Write([]byte(`{"error":0,"result":{"id":` + id + `,"hash":"","typ":"limit","amt":1000,"rat":15000,"fee":2.5,"cre":2.5,"rec":0.06666666,"ts":1702396382}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() (string, map[string]any) {
+		mu.Lock()
+		defer mu.Unlock()
+		return path, payload
+	}
+}
+
+// TestDryRun checks that a dry run sends the signed order to the test endpoints.
+func TestDryRun(t *testing.T) {
+	srv, last := dryRunServer(t)
+
+	// Per call with DryRun
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	result, err := sdk.PlaceBidCtx(bksdk.DryRun(context.Background()), "btc_thb", dec("1000"), dec("15000"), "limit", "id-1")
+	assert.NoError(t, err)
+	path, payload := last()
+	assert.Equal(t, api.MarketPlaceBidTest, path)
+	assert.Equal(t, "THB_BTC", payload["sym"])
+	assert.Equal(t, 1000.0, payload["amt"])
+	assert.Equal(t, 1702396382.0, payload["ts"])
+	assert.NotEmpty(t, payload["sig"])
+	assert.Equal(t, "0", result.ID)
+	assert.Equal(t, dec("0.06666666"), result.Rec)
+	assert.Equal(t, "id-1", result.Ci)
+
+	// Without DryRun the order goes to the real endpoint
+	_, err = sdk.PlaceAsk("btc_thb", dec("0.1"), dec("15000"), "limit", "")
+	assert.NoError(t, err)
+	path, _ = last()
+	assert.Equal(t, api.MarketPlaceAskV3, path)
+
+	// For every call with WithDryRun
+	sdk, err = bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL), bksdk.WithDryRun())
+	assert.NoError(t, err)
+
+	_, err = sdk.PlaceAsk("btc_thb", dec("0.1"), dec("15000"), "limit", "")
+	assert.NoError(t, err)
+	path, _ = last()
+	assert.Equal(t, api.MarketPlaceAskTest, path)
+
+	// The order is validated before the dry run
+	_, err = sdk.PlaceBid("btc_thb", dec("1000"), dec("15000"), "stop", "")
+	assert.Error(t, err)
+}
+
+// TestDryRunPayload checks that a dry run sends the fields of the v3 order, only the signing and the symbol format differ.
+func TestDryRunPayload(t *testing.T) {
+	srv, last := dryRunServer(t)
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		place func(ctx context.Context) error
+	}{
+		{"bid", func(ctx context.Context) error {
+			_, err := sdk.PlaceBidCtx(ctx, "btc_thb", dec("1000"), dec("15000"), "limit", "id-1")
+			return err
+		}},
+		{"ask", func(ctx context.Context) error {
+			_, err := sdk.PlaceAskCtx(ctx, "THB_BTC", dec("0.1"), dec("15000"), "limit", "")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.place(context.Background()))
+			_, live := last()
+			assert.NoError(t, tt.place(bksdk.DryRun(context.Background())))
+			_, dryRun := last()
+
+			// The v1 signing fields are only in the dry run
+			assert.NotEmpty(t, dryRun["ts"])
+			assert.NotEmpty(t, dryRun["sig"])
+			delete(dryRun, "ts")
+			delete(dryRun, "sig")
+
+			// The symbol is the same market in another convention
+			liveSym, err := bksdk.ParseSymbol(live["sym"].(string))
+			assert.NoError(t, err)
+			dryRunSym, err := bksdk.ParseSymbol(dryRun["sym"].(string))
+			assert.NoError(t, err)
+			assert.Equal(t, liveSym, dryRunSym)
+			assert.Equal(t, "btc_thb", live["sym"])
+			assert.Equal(t, "THB_BTC", dryRun["sym"])
+			delete(live, "sym")
+			delete(dryRun, "sym")
+
+			assert.Equal(t, live, dryRun)
+		})
+	}
+}
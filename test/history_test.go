@@ -0,0 +1,111 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// historyServer answers the history requests with a candle every minute of the requested range,
+// plus the candle before the range to check the de-duplication. Minutes in noData answer no_data.
+func historyServer(t *testing.T, requests *atomic.Int32, noData func(from int64) bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		from, _ := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+		to, _ := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+		if noData(from) {
+			w.Write([]byte(`{"s":"no_data"}`))
+			return
+		}
+
+		var ts, values []string
+		for at := from - from%60 - 60; at <= to; at += 60 {
+			ts = append(ts, strconv.FormatInt(at, 10))
+			values = append(values, strconv.FormatInt(at/60%1000, 10))
+		}
+		v := strings.Join(values, ",")
+		fmt.Fprintf(w, `{"s":"ok","t":[%s],"o":[%s],"h":[%s],"l":[%s],"c":[%s],"v":[%s]}`, strings.Join(ts, ","), v, v, v, v, v)
+	}))
+}
+
+// TestHistory checks the candles, the chunking and the statuses of GetHistory.
+func TestHistory(t *testing.T) {
+	from := time.Unix(1700000000, 0).Truncate(time.Minute)
+	to := from.Add(2500 * time.Minute)
+
+	var requests atomic.Int32
+	srv := historyServer(t, &requests, func(int64) bool { return false })
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	// 2501 candles are fetched in 3 requests, stitched without duplicates
+	candles, err := sdk.GetHistory("BTC_THB", "1", from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Len(t, candles, 2502)
+	for i := 1; i < len(candles); i++ {
+		assert.Equal(t, time.Minute, candles[i].Time.Sub(candles[i-1].Time))
+	}
+	assert.Equal(t, from.Add(-time.Minute).Unix(), candles[0].Time.Unix())
+	assert.Equal(t, to.Unix(), candles[len(candles)-1].Time.Unix())
+	assert.Equal(t, decimal.FromInt(to.Unix()/60%1000), candles[len(candles)-1].Close)
+
+	// An invalid resolution or range is rejected before any request
+	_, err = sdk.GetHistory("BTC_THB", "2", from, to)
+	assert.Error(t, err)
+	_, err = sdk.GetHistory("BTC_THB", "1", to, from)
+	assert.Error(t, err)
+}
+
+// TestHistoryStatus checks that the statuses other than ok are errors.
+func TestHistoryStatus(t *testing.T) {
+	from := time.Unix(1700000000, 0).Truncate(time.Minute)
+
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"no data", `{"s":"no_data"}`, bkerr.ErrNoData},
+		{"error", `{"s":"error","errmsg":"unknown symbol"}`, &bkerr.HistoryError{Status: "error", Message: "unknown symbol"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(srv.URL))
+			assert.NoError(t, err)
+
+			_, err = sdk.GetHistory("BTC_THB", "60", from, from.Add(time.Hour))
+			assert.Equal(t, tt.want, err)
+		})
+	}
+
+	// A chunk without data in a larger range is skipped
+	var requests atomic.Int32
+	srv := historyServer(t, &requests, func(at int64) bool { return at == from.Unix() })
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	candles, err := sdk.GetHistory("BTC_THB", "1", from, from.Add(1500*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, from.Add(999*time.Minute).Unix(), candles[0].Time.Unix())
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewWithOptions checks that the options are applied to every REST call.
+func TestNewWithOptions(t *testing.T) {
+	// Create a local stand-in for the Bitkub API.
+	var gotPath, gotUserAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`[{"name":"Non-secure endpoints","status":"ok","message":""}]`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret",
+		bksdk.WithBaseURL(srv.URL),
+		bksdk.WithUserAgent("bksdk-test"),
+	)
+	assert.NoError(t, err)
+
+	got, err := sdk.GetStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", got[0].Status)
+	assert.Equal(t, "/api/status", gotPath)
+	assert.Equal(t, "bksdk-test", gotUserAgent)
+}
+
+// TestWithTimeout checks that a slow server makes the call fail instead of hanging.
+func TestWithTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret",
+		bksdk.WithBaseURL(srv.URL),
+		bksdk.WithTimeout(20*time.Millisecond),
+	)
+	assert.NoError(t, err)
+
+	_, err = sdk.GetStatus()
+	assert.Error(t, err)
+}
+
+// TestWithHTTPClient checks that the injected client is used.
+func TestWithHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`1702396382000`))
+	}))
+	defer srv.Close()
+
+	// Count the requests going through the injected transport.
+	transport := &countingTransport{next: http.DefaultTransport}
+	sdk, err := bksdk.NewWithOptions("key", "secret",
+		bksdk.WithBaseURL(srv.URL),
+		bksdk.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	assert.NoError(t, err)
+
+	got, err := sdk.GetServerTime()
+	assert.NoError(t, err)
+	assert.Equal(t, "1702396382000", got)
+	assert.Equal(t, 1, transport.count)
+}
+
+// TestWithBaseURLInvalid checks that an invalid option is reported.
+func TestWithBaseURLInvalid(t *testing.T) {
+	_, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL("://bad"))
+	assert.Error(t, err)
+}
+
+type countingTransport struct {
+	next  http.RoundTripper
+	count int
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.count++
+	return c.next.RoundTrip(r)
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOrderValidate checks the field-level validation of the order builder.
+func TestOrderValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		order  *bksdk.Order
+		fields []string
+	}{
+		{"limit buy", bksdk.NewOrder().Buy("btc_thb").Limit(dec("1500000")).Amount(dec("1000")).ClientID("id-1"), nil},
+		{"market sell", bksdk.NewOrder().Sell("btc_thb").Market().Amount(dec("0.001")), nil},
+		{"no side", bksdk.NewOrder().Limit(dec("1")).Amount(dec("1")), []string{"side", "sym"}},
+		{"no type", bksdk.NewOrder().Buy("btc_thb").Amount(dec("1000")), []string{"typ"}},
+		{"v1 symbol", bksdk.NewOrder().Buy("THB_BTC").Market().Amount(dec("1000")), nil},
+		{"bad symbol", bksdk.NewOrder().Buy("btcthb").Market().Amount(dec("1000")), []string{"sym"}},
+		{"no amount", bksdk.NewOrder().Sell("btc_thb").Limit(dec("1500000")), []string{"amt"}},
+		{"negative amount", bksdk.NewOrder().Sell("btc_thb").Market().Amount(dec("-1")), []string{"amt"}},
+		{"no rate", bksdk.NewOrder().Buy("btc_thb").Limit(dec("0")).Amount(dec("1000")), []string{"rat"}},
+		{"rate of a market order", bksdk.NewOrder().Buy("btc_thb").Limit(dec("1")).Market().Amount(dec("1000")), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.Validate()
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var fieldErrs request.ValidationError
+			assert.True(t, errors.As(err, &fieldErrs))
+			var fields []string
+			for _, fieldErr := range fieldErrs {
+				fields = append(fields, fieldErr.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+
+	// The request itself rejects a market order with a rate
+	bid := request.PlaceBid{Symbol: "btc_thb", Type: "market", Amount: dec("1000"), Rate: dec("1")}
+	assert.EqualError(t, bid.Validate(), "Invalid request: rat: must be 0 for a market order")
+}
+
+// TestCancelOrderValidate checks that an order is cancelled by its hash, or by its symbol, id and side.
+func TestCancelOrderValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		order request.CancelOrder
+		err   string
+	}{
+		{"by hash", request.CancelOrder{Hash: "fwQ6dnQWQPs4cbatF5Am2xCDP1J"}, ""},
+		{"by id", request.CancelOrder{Symbol: "btc_thb", ID: "1", Side: "buy"}, ""},
+		{"bad side", request.CancelOrder{Symbol: "btc_thb", ID: "1", Side: "bid"}, `Invalid request: sd: must be one of buy, sell, got "bid"`},
+		{"no id", request.CancelOrder{Symbol: "btc_thb"}, "Invalid request: id: is required without hash, sd: is required without hash"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+// TestPlace checks that Place sends the order to the endpoint of its side.
+func TestPlace(t *testing.T) {
+	srv, last := dryRunServer(t)
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	result, err := sdk.Place(ctx, bksdk.NewOrder().Buy("btc_thb").Limit(dec("15000")).Amount(dec("1000")).ClientID("id-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result.ID)
+	path, payload := last()
+	assert.Equal(t, api.MarketPlaceBidV3, path)
+	assert.Equal(t, map[string]any{"sym": "btc_thb", "typ": "limit", "client_id": "id-1", "amt": 1000.0, "rat": 15000.0}, payload)
+
+	_, err = sdk.Place(ctx, bksdk.NewOrder().Sell("btc_thb").Market().Amount(dec("0.1")))
+	assert.NoError(t, err)
+	path, payload = last()
+	assert.Equal(t, api.MarketPlaceAskV3, path)
+	assert.Equal(t, 0.0, payload["rat"])
+
+	// A dry run applies to Place
+	_, err = sdk.Place(bksdk.DryRun(ctx), bksdk.NewOrder().Sell("btc_thb").Market().Amount(dec("0.1")))
+	assert.NoError(t, err)
+	path, _ = last()
+	assert.Equal(t, api.MarketPlaceAskTest, path)
+
+	// An invalid order is not sent
+	_, err = sdk.Place(ctx, bksdk.NewOrder().Buy("btc_thb").Amount(dec("1000")))
+	assert.Error(t, err)
+	path, _ = last()
+	assert.Equal(t, api.MarketPlaceAskTest, path)
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/orderbook"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOrderBook checks the bootstrap, the change events and the resync of the local order book.
+func TestOrderBook(t *testing.T) {
+	wsSrv := newWsServer(t)
+
+	var booksCalls atomic.Int32
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api.MarketSymbol:
+			w.Write([]byte(`{"error":0,"result":[{"id":1,"symbol":"THB_BTC","info":"Thai Baht to Bitcoin"},{"id":2,"symbol":"THB_ETH","info":"Thai Baht to Ethereum"}]}`))
+		case api.MarketBooks:
+			booksCalls.Add(1)
+			assert.Equal(t, "thb_eth", r.URL.Query().Get("sym"))
+			w.Write([]byte(`{"error":0,"result":{
+				"bids":[[1,1529453033,500,50000,0.01],[2,1529453034,250,50000,0.005],[3,1529453035,490,49000,0.01]],
+				"asks":[[4,1529453036,510,51000,0.01],[5,1529453037,520,52000,0.01]]}}`))
+		}
+	}))
+	defer apiSrv.Close()
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(apiSrv.URL), bksdk.WithWSHost(wsSrv.host()))
+	assert.NoError(t, err)
+
+	snapshots := make(chan orderbook.Snapshot, 16)
+	book := orderbook.New(sdk, "thb_eth", orderbook.WithSnapshotHandler(func(s orderbook.Snapshot) {
+		snapshots <- s
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- book.Run(ctx)
+	}()
+
+	waitSnapshot := func() orderbook.Snapshot {
+		select {
+		case s := <-snapshots:
+			return s
+		case <-time.After(5 * time.Second):
+			t.Fatal("no snapshot")
+			return orderbook.Snapshot{}
+		}
+	}
+
+	// The stream is named after the pairing id, and the book is bootstrapped from GetBooks with aggregated rates
+	assert.Equal(t, "orderbook/2", wsSrv.waitDial(t))
+	snapshot := waitSnapshot()
+	assert.Equal(t, "THB_ETH", snapshot.Symbol)
+	assert.Equal(t, []orderbook.Level{{Rate: dec("50000"), Amount: dec("0.015"), Volume: dec("750")}, {Rate: dec("49000"), Amount: dec("0.01"), Volume: dec("490")}}, snapshot.Bids)
+
+	spread, ok := book.Spread()
+	assert.True(t, ok)
+	assert.Equal(t, dec("1000"), spread)
+
+	// A change event replaces its side, events of other pairs are ignored
+	wsSrv.send(t, `{"data":[[121.82,50500,0.00241228,0,false,false],[100,50400,0.00198413,0,false,false]],"event":"bidschanged","pairing_id":1}`+"\n"+
+		`{"data":[[121.82,50500,0.00241228,0,false,false],[100,50400,0.00198413,0,false,false]],"event":"bidschanged","pairing_id":2}`)
+	waitSnapshot()
+	bid, ok := book.BestBid()
+	assert.True(t, ok)
+	assert.Equal(t, dec("50500"), bid.Rate)
+
+	bids, asks := book.Depth(1)
+	assert.Len(t, bids, 1)
+	assert.Equal(t, dec("51000"), asks[0].Rate)
+
+	// A crossed book triggers a resync from GetBooks
+	wsSrv.send(t, `{"data":[[100,50450,0.00198216,0,false,false]],"event":"askschanged","pairing_id":2}`)
+	snapshot = waitSnapshot()
+	assert.Equal(t, int32(2), booksCalls.Load())
+	assert.Equal(t, 2, book.Resyncs())
+	assert.Equal(t, dec("50000"), snapshot.Bids[0].Rate)
+	assert.Equal(t, dec("51000"), snapshot.Asks[0].Rate)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+// TestOrderBookUnknownSymbol checks that Run fails when the symbol is not listed.
+func TestOrderBookUnknownSymbol(t *testing.T) {
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":0,"result":[{"id":1,"symbol":"THB_BTC","info":"Thai Baht to Bitcoin"}]}`))
+	}))
+	defer apiSrv.Close()
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(apiSrv.URL))
+	assert.NoError(t, err)
+
+	err = orderbook.New(sdk, "thb_doge").Run(context.Background())
+	assert.ErrorIs(t, err, orderbook.ErrUnknownSymbol)
+}
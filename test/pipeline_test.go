@@ -0,0 +1,125 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResponsePipeline checks that every kind of failure becomes a typed error,
+// for public and secure endpoints alike.
+func TestResponsePipeline(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{
+			name:   "too many requests",
+			status: http.StatusTooManyRequests,
+			body:   `Too Many Requests`,
+			check: func(t *testing.T, err error) {
+				var httpErr *bkerr.HTTPError
+				assert.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+				assert.True(t, bkerr.IsRetryable(err))
+			},
+		},
+		{
+			name:   "html error page",
+			status: http.StatusBadGateway,
+			body:   `<html><body>502 Bad Gateway</body></html>`,
+			check: func(t *testing.T, err error) {
+				var httpErr *bkerr.HTTPError
+				assert.True(t, errors.As(err, &httpErr))
+				assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+				assert.Contains(t, httpErr.Body, "502 Bad Gateway")
+			},
+		},
+		{
+			name:   "error code with non-2xx status",
+			status: http.StatusUnauthorized,
+			body:   `{"error":3}`,
+			check: func(t *testing.T, err error) {
+				var apiErr *bkerr.APIError
+				assert.True(t, errors.As(err, &apiErr))
+				assert.Equal(t, bkerr.InvalidAPIKey, apiErr.Code)
+				assert.Equal(t, http.StatusUnauthorized, apiErr.HTTPStatus)
+				assert.True(t, bkerr.IsAuthError(err))
+			},
+		},
+		{
+			name:   "undecodable body",
+			status: http.StatusOK,
+			body:   `{"error":0,"result":"not a list"}`,
+			check: func(t *testing.T, err error) {
+				var decodeErr *bkerr.DecodeError
+				assert.True(t, errors.As(err, &decodeErr))
+				assert.False(t, bkerr.IsRetryable(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == api.ServertimeV3 {
+					w.Write([]byte(`1702396382000`))
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+			assert.NoError(t, err)
+
+			// Public endpoint
+			_, err = sdk.GetSymbols()
+			tt.check(t, err)
+
+			// Secure endpoint
+			_, err = sdk.MyOpenOrder("btc_thb")
+			tt.check(t, err)
+		})
+	}
+}
+
+// TestServerTimeError checks that a failing server time request returns an error instead of panicking.
+func TestServerTimeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	_, err = sdk.GetServerTime()
+	var httpErr *bkerr.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+}
+
+// TestTransportError checks that a connection failure is returned as a transport error.
+func TestTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	_, err = sdk.GetTicker("btc_thb")
+	var transportErr *bkerr.TransportError
+	assert.True(t, errors.As(err, &transportErr))
+	assert.Equal(t, api.MarketTicker, transportErr.Endpoint)
+	assert.True(t, bkerr.IsRetryable(err))
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPlaceAskByFiat checks the payload signature, the typed response and the errors of PlaceAskByFiat.
+func TestPlaceAskByFiat(t *testing.T) {
+	var errorCode string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			w.Write([]byte(`1702396382000`))
+			return
+		}
+		assert.Equal(t, api.MarketPlaceAskByFiat, r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("X-BTK-APIKEY"))
+		assert.Empty(t, r.Header.Get("X-BTK-SIGN"))
+
+		// The payload carries the timestamp in seconds and the signature of the rest of the payload
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "THB_BTC", payload["sym"])
+		assert.Equal(t, 1000.0, payload["amt"])
+		assert.Equal(t, 1702396382.0, payload["ts"])
+
+		signed, _, _ := strings.Cut(string(body), `,"sig":`)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(signed + "}"))
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), payload["sig"])
+
+		if errorCode != "" {
+			w.Write([]byte(`{"error":` + errorCode + `}`))
+			return
+		}
+		fixture, _ := os.ReadFile(filepath.Join("testdata", "place_ask_by_fiat.json"))
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	result, err := sdk.PlaceAskByFiat("THB_BTC", dec("1000"), dec("1506750.5"), "limit")
+	assert.NoError(t, err)
+	assert.Equal(t, response.PlaceAskByFiatResult{
+		ID:   "1",
+		Hash: "fwQ6dnQWQPs4cbatF5Am2xCDP1J",
+		Typ:  "limit",
+		Amt:  dec("0.00066372"),
+		Rat:  dec("1506750.5"),
+		Fee:  dec("2.5"),
+		Cre:  dec("2.5"),
+		Rec:  dec("997.5"),
+		Ts:   1533834844,
+	}, result)
+
+	// An error code is returned like the other order endpoints
+	errorCode = "18"
+	_, err = sdk.PlaceAskByFiat("THB_BTC", dec("1000"), decimal.Zero, "market")
+	assert.ErrorIs(t, err, bkerr.ErrInsufficientBalance)
+
+	// Invalid orders are rejected before any request
+	tests := []struct {
+		name string
+		sym  string
+		amt  decimal.Decimal
+		rat  decimal.Decimal
+		typ  string
+	}{
+		{"no symbol", "", dec("1000"), dec("1"), "limit"},
+		{"no amount", "THB_BTC", decimal.Zero, dec("1"), "limit"},
+		{"no rate", "THB_BTC", dec("1000"), decimal.Zero, "limit"},
+		{"bad type", "THB_BTC", dec("1000"), dec("1"), "stop"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sdk.PlaceAskByFiat(tt.sym, tt.amt, tt.rat, tt.typ)
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, bkerr.ErrInsufficientBalance)
+		})
+	}
+}
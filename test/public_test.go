@@ -1,7 +1,6 @@
 package test
 
 import (
-	"fmt"
 	"os"
 	"testing"
 
@@ -36,10 +35,15 @@ func TestGetBids(t *testing.T) {
 			args: args{"THB_BTC", 1},
 			want: response.MarketBids{},
 		},
+		{
+			name: "should accept the symbol in any convention",
+			args: args{"BTC_THB", 1},
+			want: response.MarketResult{},
+		},
 		{
 			name:    "should error when not found symbol",
-			args:    args{"BTC_THB", 1},
-			wantErr: fmt.Errorf(bkerr.ErrorText(bkerr.InvalidSymbol)),
+			args:    args{"THB_NOPE", 1},
+			wantErr: bkerr.ErrInvalidSymbol,
 		},
 	}
 
@@ -55,13 +59,20 @@ func TestGetBids(t *testing.T) {
 			// Perform assertions based on the test case name.
 			switch tt.name {
 			case "should return 5 bids data of BTC":
-				assert.Equal(t, tt.want, len(got[0]))
+				assert.Equal(t, tt.want, len(got[0].Raw))
 			case "should return MarketBids type":
 				assert.IsType(t, tt.want, got)
+			case "should accept the symbol in any convention":
+				assert.IsType(t, tt.want, got)
+				assert.NotEmpty(t, got)
 			}
 
 			// Assert that the error matches the expected error.
-			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRateLimit checks that concurrent calls sharing one SDK are spread by the limiter of their group.
+func TestRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret",
+		bksdk.WithBaseURL(srv.URL),
+		bksdk.WithRateLimit(api.GroupMarketData, bksdk.RateLimit{Rate: 20, Burst: 1}),
+	)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := sdk.GetTicker("")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// 1 request goes through right away, the next 4 wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+
+	stats := sdk.RateLimitStats()[api.GroupMarketData]
+	assert.Equal(t, int64(5), stats.Requests)
+	assert.Equal(t, int64(4), stats.Throttled)
+	assert.Greater(t, stats.WaitTime, time.Duration(0))
+}
+
+// TestRateLimitCtx checks that waiting for the limiter respects the context.
+func TestRateLimitCtx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret",
+		bksdk.WithBaseURL(srv.URL),
+		bksdk.WithRateLimit(api.GroupMarketData, bksdk.RateLimit{Rate: 0.1, Burst: 1}),
+	)
+	assert.NoError(t, err)
+
+	// The first call takes the only token
+	_, err = sdk.GetTicker("")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = sdk.GetTickerCtx(ctx, "")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+// TestRateLimitDepth checks that the depth endpoint is limited on its own, below the other market data endpoints.
+func TestRateLimitDepth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	// The burst of 10 goes through right away, the 11th waits for a token
+	for i := 0; i < 11; i++ {
+		_, err := sdk.GetDepth("thb_btc", 1)
+		assert.NoError(t, err)
+	}
+
+	stats := sdk.RateLimitStats()
+	assert.Equal(t, int64(11), stats[api.GroupDepth].Requests)
+	assert.Equal(t, int64(1), stats[api.GroupDepth].Throttled)
+	assert.Equal(t, int64(0), stats[api.GroupMarketData].Requests)
+}
+
+// TestGroupOf checks the endpoint groups.
+func TestGroupOf(t *testing.T) {
+	assert.Equal(t, api.GroupMarketData, api.GroupOf(api.MarketTicker))
+	assert.Equal(t, api.GroupMarketData, api.GroupOf(api.ServertimeV3))
+	assert.Equal(t, api.GroupDepth, api.GroupOf(api.MarketDepth))
+	assert.Equal(t, api.GroupTrading, api.GroupOf(api.MarketPlaceBidV3))
+	assert.Equal(t, api.GroupTrading, api.GroupOf(api.MarketMyOpenOrderV3))
+	assert.Equal(t, api.GroupWallet, api.GroupOf(api.MarketBalancesV3))
+	assert.Equal(t, api.GroupWallet, api.GroupOf(api.FiatWithdrawV3))
+}
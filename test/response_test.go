@@ -0,0 +1,261 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixtureServer serves the fixture file of test/testdata registered for each endpoint.
+func fixtureServer(t *testing.T, fixtures map[string]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			w.Write([]byte(`1702396382000`))
+			return
+		}
+
+		name, ok := fixtures[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Errorf("read fixture %s: %v", name, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// dec is a shorthand for decimal.MustParse.
+func dec(s string) decimal.Decimal {
+	return decimal.MustParse(s)
+}
+
+// TestResponseFixtures checks that every secure endpoint decodes real payloads carrying fractional
+// amounts, rates and fees, numbers sent as strings and timestamps sent as strings.
+func TestResponseFixtures(t *testing.T) {
+	srv := fixtureServer(t, map[string]string{
+		api.MarketMyOpenOrderV3:      "my_open_orders.json",
+		api.MarketMyOrderHistoryV3:   "my_order_history.json",
+		api.MarketOrderInfoV3:        "order_info.json",
+		api.UserTradingCreditsV3:     "trading_credits.json",
+		api.UserLimitsV3:             "limits.json",
+		api.MarketWalletV3:           "wallet.json",
+		api.MarketBalancesV3:         "balances.json",
+		api.MarketPlaceBidV3:         "place_bid.json",
+		api.MarketPlaceAskV3:         "place_ask.json",
+		api.CryptoAddressesV3:        "crypto_addresses.json",
+		api.CryptoWithdrawV3:         "crypto_withdraw.json",
+		api.CryptoInternalWithdrawV3: "internal_withdraw.json",
+		api.CryptoDepositHistoryV3:   "crypto_deposit_history.json",
+		api.CryptoWithdrawHistoryV3:  "crypto_withdraw_history.json",
+		api.FiatAccountsV3:           "fiat_accounts.json",
+		api.FiatWithdrawV3:           "fiat_withdraw.json",
+		api.FiatDepositHistoryV3:     "fiat_deposit_history.json",
+		api.FiatWithdrawHistoryV3:    "fiat_withdraw_history.json",
+	})
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL), bksdk.WithRetryPolicy(bksdk.NoRetry))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		check func(t *testing.T)
+	}{
+		{name: "my open orders", check: func(t *testing.T) {
+			orders, err := sdk.MyOpenOrder("doge_thb")
+			assert.NoError(t, err)
+			assert.Len(t, orders, 1)
+			assert.Equal(t, dec("0.4521"), orders[0].Rate)
+			assert.Equal(t, dec("0.0011"), orders[0].Fee)
+			assert.Equal(t, dec("1520.75"), orders[0].Amount)
+			assert.Equal(t, dec("687.51"), orders[0].Receive)
+			assert.Equal(t, response.StringOrNumber("1"), orders[0].ParentID)
+			assert.Equal(t, time.UnixMilli(1702543272000), orders[0].Ts.Time())
+		}},
+		{name: "my order history", check: func(t *testing.T) {
+			orders, page, err := sdk.MyOrderHistory("doge_thb", 2, 10, 0, 0)
+			assert.NoError(t, err)
+			assert.Len(t, orders, 1)
+			assert.Equal(t, dec("2.8134"), orders[0].Rate)
+			assert.Equal(t, dec("0.00703"), orders[0].Fee)
+			assert.Equal(t, dec("10.123456"), orders[0].Amount)
+			assert.Equal(t, "bot-1", orders[0].ClientID)
+			assert.Equal(t, 3, page.Next)
+		}},
+		{name: "order info", check: func(t *testing.T) {
+			info, err := sdk.OrderInfo("doge_thb", "289", "buy")
+			assert.NoError(t, err)
+			assert.Equal(t, dec("0.0912"), info.Rate)
+			assert.Equal(t, dec("10.0013"), info.Fee)
+			assert.Equal(t, dec("0.25"), info.Credit)
+			assert.Equal(t, dec("4000.5"), info.Total)
+			assert.Equal(t, dec("0.53"), info.Remaining)
+			assert.Equal(t, dec("0.0912"), info.History[0].Rate)
+			assert.Equal(t, time.Unix(1525944169, 0), info.History[0].Timestamp.Time())
+		}},
+		{name: "trading credits", check: func(t *testing.T) {
+			credit, err := sdk.TradingCredit()
+			assert.NoError(t, err)
+			assert.Equal(t, dec("0.25"), credit)
+		}},
+		{name: "limits", check: func(t *testing.T) {
+			limits, err := sdk.Limits()
+			assert.NoError(t, err)
+			assert.Equal(t, dec("0.88971929"), limits.Limits.Crypto.Deposit)
+			assert.Equal(t, dec("200000.5"), limits.Limits.Fiat.Withdraw)
+			assert.Equal(t, dec("100.25"), limits.Usage.Fiat.Withdraw)
+			assert.Equal(t, dec("224790.01"), limits.Rate)
+		}},
+		{name: "wallet", check: func(t *testing.T) {
+			wallet, err := sdk.Wallet()
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"BTC", "DOGE", "ETH", "KUB", "SHIB", "THB"}, wallet.Currencies())
+			assert.Equal(t, []string{"BTC", "ETH", "KUB", "SHIB", "THB"}, wallet.NonZero().Currencies())
+
+			btc, ok := wallet.Get("btc")
+			assert.True(t, ok)
+			assert.Equal(t, dec("8.90397323"), btc)
+
+			kub, ok := wallet.Get("Kub")
+			assert.True(t, ok)
+			assert.Equal(t, dec("1520.75"), kub)
+
+			_, ok = wallet.Get("xrp")
+			assert.False(t, ok)
+		}},
+		{name: "balances", check: func(t *testing.T) {
+			balances, err := sdk.Balances()
+			assert.NoError(t, err)
+			assert.Equal(t, dec("0.01"), balances["THB"].Reserved)
+			assert.Equal(t, dec("12345678.12345678"), balances["SHIB"].Available)
+		}},
+		{name: "place bid", check: func(t *testing.T) {
+			order, err := sdk.PlaceBid("doge_thb", dec("1000.5"), dec("0.0912"), "limit", "input_client_id")
+			assert.NoError(t, err)
+			assert.Equal(t, dec("1000.5"), order.Amt)
+			assert.Equal(t, dec("0.0912"), order.Rat)
+			assert.Equal(t, dec("10943.53"), order.Rec)
+			assert.Equal(t, response.Timestamp(1707220636), order.Ts)
+		}},
+		{name: "place ask", check: func(t *testing.T) {
+			order, err := sdk.PlaceAsk("doge_thb", dec("10943.53"), dec("0.0912"), "limit", "input_client_id")
+			assert.NoError(t, err)
+			assert.Equal(t, dec("0.0912"), order.Rat)
+			assert.Equal(t, dec("995.56"), order.Rec)
+		}},
+		{name: "crypto addresses", check: func(t *testing.T) {
+			addresses, _, err := sdk.CryptoAddresses(1, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, response.StringOrNumber("0"), addresses[0].Tag)
+			assert.Equal(t, response.StringOrNumber("memo-123"), addresses[1].Tag)
+		}},
+		{name: "crypto withdraw", check: func(t *testing.T) {
+			withdraw, err := sdk.CryptoWithdraw("BTC", "4asyjKw6XScneNvhJTLVHS9XfNYM7VAe8k", "", dec("0.1"), "BTC")
+			assert.NoError(t, err)
+			assert.Equal(t, dec("0.0002"), withdraw.Fee)
+		}},
+		{name: "crypto internal withdraw", check: func(t *testing.T) {
+			withdraw, err := sdk.CryptoInternalWithdraw("BTC", "4asyjKw6XScneNvhJTLVHS9XfNYM7VAe8k", "", dec("0.00012345"))
+			assert.NoError(t, err)
+			assert.Equal(t, dec("0.00012345"), withdraw.Amt)
+		}},
+		{name: "crypto deposit history", check: func(t *testing.T) {
+			history, _, err := sdk.CryptoDepositHistory(1, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, dec("5.75"), history[0].Amount)
+		}},
+		{name: "crypto withdraw history", check: func(t *testing.T) {
+			history, _, err := sdk.CryptoWithdrawHistory(1, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, dec("5.75"), history[0].Amount)
+			assert.Equal(t, dec("0.01"), history[0].Fee)
+		}},
+		{name: "fiat accounts", check: func(t *testing.T) {
+			accounts, _, err := sdk.FiatAccounts(1, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, "7262109099", accounts[0].ID)
+		}},
+		{name: "fiat withdraw", check: func(t *testing.T) {
+			withdraw, err := sdk.FiatWithdraw("7262109099", dec("21.5"))
+			assert.NoError(t, err)
+			assert.Equal(t, dec("21.5"), withdraw.Amt)
+			assert.Equal(t, dec("20.25"), withdraw.Fee)
+			assert.Equal(t, dec("1.25"), withdraw.Rec)
+		}},
+		{name: "fiat deposit history", check: func(t *testing.T) {
+			history, _, err := sdk.FiatDepositHistory(1, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, dec("5000.55"), history[0].Amount)
+		}},
+		{name: "fiat withdraw history", check: func(t *testing.T) {
+			history, _, err := sdk.FiatWithdrawHistory(1, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, dec("21.5"), history[0].Amount)
+			assert.Equal(t, dec("20.25"), history[0].Fee)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.check)
+	}
+}
+
+// TestBookFixtures checks that the order book and trade tuples decode into typed entries
+// and keep their raw form.
+func TestBookFixtures(t *testing.T) {
+	srv := fixtureServer(t, map[string]string{
+		api.MarketBids:   "market_bids.json",
+		api.MarketBooks:  "market_books.json",
+		api.MarketTrades: "market_trades.json",
+	})
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	bids, err := sdk.GetBids("thb_btc", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, response.BookEntry{
+		OrderID:   "2",
+		Timestamp: 1529453034,
+		Volume:    dec("0.5"),
+		Rate:      dec("0.0912"),
+		Amount:    dec("5.48245614"),
+		Raw:       [5]any{float64(2), float64(1529453034), 0.5, 0.0912, 5.48245614},
+	}, bids[1])
+	assert.Equal(t, [][5]any{bids[0].Raw, bids[1].Raw}, bids.Raw())
+
+	books, err := sdk.GetBooks("thb_btc", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", books.Bids[0].OrderID)
+	assert.Equal(t, "681", books.Asks[1].OrderID)
+	assert.Equal(t, dec("10001.5"), books.Asks[1].Rate)
+	assert.Equal(t, dec("0.00124981"), books.Asks[1].Amount)
+
+	trades, err := sdk.GetTrade("thb_btc", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, response.Timestamp(1529516288), trades[1].Timestamp)
+	assert.Equal(t, dec("0.0912"), trades[1].Rate)
+	assert.Equal(t, dec("5.48245614"), trades[1].Amount)
+	assert.Equal(t, "sell", trades[1].Side)
+	assert.Equal(t, "BUY", trades.Raw()[0][3])
+
+	// A malformed tuple is a decode error instead of a panic in the caller
+	var entry response.BookEntry
+	assert.Error(t, json.Unmarshal([]byte(`[1, 1529453033, 997.5]`), &entry))
+}
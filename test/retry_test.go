@@ -0,0 +1,122 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// fastRetry keeps the tests quick.
+var fastRetry = bksdk.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+// TestRetryRead checks that a read endpoint is retried on 5xx and on the server error code.
+func TestRetryRead(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Write([]byte(`{"error":90}`))
+		default:
+			w.Write([]byte(`{"error":0,"result":[{"id":1,"symbol":"THB_BTC","info":"Thai Baht to Bitcoin"}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL), bksdk.WithRetryPolicy(fastRetry))
+	assert.NoError(t, err)
+
+	got, err := sdk.GetSymbols()
+	assert.NoError(t, err)
+	assert.Equal(t, "THB_BTC", got[0].Symbol)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+// TestRetryGiveUp checks that the last error is returned after the last attempt.
+func TestRetryGiveUp(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL), bksdk.WithRetryPolicy(fastRetry))
+	assert.NoError(t, err)
+
+	_, err = sdk.GetTicker("")
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+// TestRetryOrder checks that order placement is only retried when enabled and keyed by client_id.
+func TestRetryOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		orderRetry bool
+		clientID   string
+		filled     bool
+		wantErr    bool
+		wantPlaced int32
+	}{
+		{name: "not retried by default", clientID: "bot-1", wantErr: true, wantPlaced: 1},
+		{name: "not retried without client_id", orderRetry: true, wantErr: true, wantPlaced: 1},
+		{name: "previous attempt found by client_id", orderRetry: true, clientID: "bot-1", wantPlaced: 1},
+		{name: "filled previous attempt found in the history", orderRetry: true, clientID: "bot-1", filled: true, wantPlaced: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var placed int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case api.ServertimeV3:
+					w.Write([]byte(`1702396382000`))
+				case api.MarketPlaceBidV3:
+					// The order is created but the response is lost
+					atomic.AddInt32(&placed, 1)
+					w.WriteHeader(http.StatusBadGateway)
+				case api.MarketMyOpenOrderV3:
+					if tt.filled {
+						w.Write([]byte(`{"error":0,"result":[]}`))
+						return
+					}
+					w.Write([]byte(`{"error":0,"result":[{"id":"2","hash":"fwQ6dnQWQPs4cbatFSJpMCcKTFR","side":"buy","type":"limit","rate":15000,"fee":0.35,"credit":0,"amount":1000,"receive":0,"parent_id":0,"super_id":0,"client_id":"bot-1","ts":1702543272000}]}`))
+				case api.MarketMyOrderHistoryV3:
+					// An order of the other side with the same client_id is not the lost order
+					w.Write([]byte(`{"error":0,"result":[` +
+						`{"txn_id":"BTCSELL1","order_id":"1","hash":"other","side":"sell","type":"limit","rate":"15000","fee":"0.35","credit":"0","amount":"0.1","client_id":"bot-1","ts":1702543272000},` +
+						`{"txn_id":"BTCBUY2","order_id":"2","hash":"fwQ6dnQWQPs4cbatFSJpMCcKTFR","side":"buy","type":"limit","rate":"15000","fee":"0.35","credit":"0","amount":"1000","client_id":"bot-1","ts":1702543272000}` +
+						`],"pagination":{"page":1,"last":1}}`))
+				}
+			}))
+			defer srv.Close()
+
+			opts := []bksdk.Option{bksdk.WithBaseURL(srv.URL), bksdk.WithRetryPolicy(fastRetry)}
+			if tt.orderRetry {
+				opts = append(opts, bksdk.WithOrderRetry())
+			}
+			sdk, err := bksdk.NewWithOptions("key", "secret", opts...)
+			assert.NoError(t, err)
+
+			got, err := sdk.PlaceBid("btc_thb", decimal.FromInt(1000), decimal.FromInt(15000), "limit", tt.clientID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "fwQ6dnQWQPs4cbatFSJpMCcKTFR", got.Hash)
+				assert.Equal(t, "2", got.ID)
+				assert.Equal(t, "bot-1", got.Ci)
+			}
+			assert.Equal(t, tt.wantPlaced, atomic.LoadInt32(&placed))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStreamBuilders checks the normalisation of the symbols and the validation against GetSymbols.
+func TestStreamBuilders(t *testing.T) {
+	assert.Equal(t, "market.ticker.thb_btc", bksdk.TickerStream("THB_BTC"))
+	assert.Equal(t, "market.trade.thb_btc", bksdk.TradeStream(" thb-btc "))
+	assert.Equal(t, "orderbook/1", bksdk.OrderBookStream(1))
+	assert.Equal(t, "thb_btc", bksdk.NormalizeSymbol("THB/BTC"))
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":0,"result":[{"id":1,"symbol":"THB_BTC","info":"Thai Baht to Bitcoin"},{"id":2,"symbol":"THB_ETH","info":"Thai Baht to Ethereum"}]}`))
+	}))
+	defer apiSrv.Close()
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(apiSrv.URL))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		build   func(ctx context.Context, symbols ...string) ([]string, error)
+		symbols []string
+		want    []string
+	}{
+		{"ticker", sdk.TickerStreams, []string{"THB_BTC", "eth_thb"}, []string{"market.ticker.thb_btc", "market.ticker.thb_eth"}},
+		{"trade", sdk.TradeStreams, []string{"btc-thb", "thb_btc"}, []string{"market.trade.thb_btc"}},
+		{"order book", sdk.OrderBookStreams, []string{"thb_eth"}, []string{"orderbook/2"}},
+		{"every market", sdk.TickerStreams, nil, []string{"market.ticker.thb_btc", "market.ticker.thb_eth"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build(ctx, tt.symbols...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Every unknown symbol is reported
+	_, err = sdk.TradeStreams(ctx, "thb_btc", "thb_doge", "xyz")
+	assert.ErrorIs(t, err, bkerr.ErrUnknownSymbol)
+	assert.ErrorContains(t, err, "thb_doge, xyz")
+}
+
+// TestWsClientShards checks that large subscriptions are split across connections
+// and that a change only reconnects the shards whose streams changed.
+func TestWsClientShards(t *testing.T) {
+	srv := newWsServer(t)
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithWSHost(srv.host()))
+	assert.NoError(t, err)
+
+	client := sdk.NewWsClient(context.Background(), bksdk.WithWsMaxStreams(2))
+	defer client.Close()
+
+	assert.NoError(t, client.Subscribe("s1", "s2", "s3", "s4", "s5"))
+	dials := []string{srv.waitDial(t), srv.waitDial(t), srv.waitDial(t)}
+	sort.Strings(dials)
+	assert.Equal(t, []string{"s1,s2", "s3,s4", "s5"}, dials)
+
+	// Adding a stream only reconnects the last shard
+	assert.NoError(t, client.Subscribe("s6"))
+	assert.Equal(t, "s5,s6", srv.waitDial(t))
+	select {
+	case streams := <-srv.dials:
+		t.Fatalf("unexpected connection with %s", streams)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Removing a stream of the first shard only reconnects that shard, the other streams keep their shards
+	assert.NoError(t, client.Unsubscribe("s1"))
+	assert.Equal(t, "s2", srv.waitDial(t))
+	select {
+	case streams := <-srv.dials:
+		t.Fatalf("unexpected connection with %s", streams)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A new stream takes the free place of the first shard
+	assert.NoError(t, client.Subscribe("s7"))
+	assert.Equal(t, "s2,s7", srv.waitDial(t))
+
+	// Every shard delivers its messages
+	srv.send(t, `{"stream":"market.ticker.thb_btc","id":1,"last":1504031.12}`)
+	ticker := <-client.Tickers()
+	assert.Equal(t, dec("1504031.12"), ticker.Last)
+}
+
+// TestWsClientShardsOrderBook checks that every order book stream has a shard of its own,
+// while the market streams are joined.
+func TestWsClientShardsOrderBook(t *testing.T) {
+	srv := newWsServer(t)
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithWSHost(srv.host()))
+	assert.NoError(t, err)
+
+	client := sdk.NewWsClient(context.Background())
+	defer client.Close()
+
+	assert.NoError(t, client.Subscribe("market.ticker.thb_btc", bksdk.OrderBookStream(1), "market.trade.thb_btc", bksdk.OrderBookStream(2)))
+	dials := []string{srv.waitDial(t), srv.waitDial(t), srv.waitDial(t)}
+	sort.Strings(dials)
+	assert.Equal(t, []string{"market.ticker.thb_btc,market.trade.thb_btc", "orderbook/1", "orderbook/2"}, dials)
+
+	// A new market stream joins the market shard, a new order book stream opens another shard
+	assert.NoError(t, client.Subscribe("market.ticker.thb_eth", bksdk.OrderBookStream(3)))
+	dials = []string{srv.waitDial(t), srv.waitDial(t)}
+	sort.Strings(dials)
+	assert.Equal(t, []string{"market.ticker.thb_btc,market.trade.thb_btc,market.ticker.thb_eth", "orderbook/3"}, dials)
+	select {
+	case streams := <-srv.dials:
+		t.Fatalf("unexpected connection with %s", streams)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestWsClientShardBlocked checks that a shard blocked on a full channel does not hold a change of the streams.
+func TestWsClientShardBlocked(t *testing.T) {
+	srv := newWsServer(t)
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithWSHost(srv.host()))
+	assert.NoError(t, err)
+
+	client := sdk.NewWsClient(context.Background(), bksdk.WithWsBufferSize(1))
+	defer client.Close()
+
+	// The trades are never read, so the reader blocks on the second one
+	assert.NoError(t, client.Subscribe("market.trade.thb_btc"))
+	assert.Equal(t, "market.trade.thb_btc", srv.waitDial(t))
+	srv.send(t, `{"stream":"market.trade.thb_btc","txn":"1"}`+"\n"+`{"stream":"market.trade.thb_btc","txn":"2"}`)
+	time.Sleep(50 * time.Millisecond)
+
+	// The shard is stopped and reconnected with the new stream anyway
+	assert.NoError(t, client.Subscribe("market.ticker.thb_btc"))
+	assert.Equal(t, "market.trade.thb_btc,market.ticker.thb_btc", srv.waitDial(t))
+
+	srv.send(t, `{"stream":"market.ticker.thb_btc","id":1,"last":1504031.12}`)
+	select {
+	case ticker := <-client.Tickers():
+		assert.Equal(t, dec("1504031.12"), ticker.Last)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no ticker")
+	}
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseSymbol checks that every convention of the API gives the same symbol.
+func TestParseSymbol(t *testing.T) {
+	btc := bksdk.Symbol{Base: "BTC", Quote: "THB"}
+	tests := []struct {
+		symbol string
+		want   bksdk.Symbol
+	}{
+		{"btc_thb", btc},
+		{"BTC_THB", btc},
+		{"thb_btc", btc},
+		{"THB_BTC", btc},
+		{" thb-btc ", btc},
+		{"BTC/THB", btc},
+		{"1inch_thb", bksdk.Symbol{Base: "1INCH", Quote: "THB"}},
+		{"eth_usdt", bksdk.Symbol{Base: "ETH", Quote: "USDT"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			got, err := bksdk.ParseSymbol(tt.symbol)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, symbol := range []string{"", "btcthb", "btc_", "btc_thb_eth", "btc thb"} {
+		_, err := bksdk.ParseSymbol(symbol)
+		assert.ErrorIs(t, err, bkerr.ErrInvalidSymbol, symbol)
+	}
+
+	assert.Equal(t, "btc_thb", btc.String())
+	assert.Equal(t, "btc_thb", btc.Format(bksdk.FormatV3))
+	assert.Equal(t, "BTC_THB", btc.Format(bksdk.FormatHistory))
+	assert.Equal(t, "thb_btc", btc.Format(bksdk.FormatStream))
+	assert.Equal(t, "THB_BTC", btc.Format(bksdk.FormatLegacy))
+}
+
+// TestSymbolConventions checks that each endpoint sends the symbol in its own convention.
+func TestSymbolConventions(t *testing.T) {
+	var mu sync.Mutex
+	sent := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == api.ServertimeV3 {
+			w.Write([]byte(`1702396382000`))
+			return
+		}
+
+		// Record the symbol of the query or of the payload
+		sym := r.URL.Query().Get("sym")
+		if r.Method == http.MethodPost {
+			var payload struct {
+				Symbol string `json:"sym"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &payload)
+			sym = payload.Symbol
+		}
+		mu.Lock()
+		sent[r.URL.Path] = sym
+		mu.Unlock()
+		w.Write([]byte(`{"error":0}`))
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	// The same symbol is given in another convention than the endpoint's each time
+	sdk.GetTickerCtx(ctx, "btc_thb")
+	sdk.GetBooksCtx(ctx, "BTC_THB", 10)
+	sdk.GetBidsCtx(ctx, "BTC_THB", 1)
+	sdk.GetHistoryCtx(ctx, "thb_btc", "60", time.Unix(1702396382, 0), time.Unix(1702396382, 0))
+	sdk.MyOpenOrderCtx(ctx, "THB_BTC")
+	sdk.PlaceBidCtx(ctx, "THB_BTC", dec("1000"), dec("1500000"), "limit", "")
+	sdk.CancelOrderCtx(ctx, "btc_thb", "1", "buy", "")
+	sdk.PlaceAskByFiatCtx(ctx, "btc_thb", dec("1000"), dec("0"), "market")
+
+	assert.Equal(t, map[string]string{
+		api.MarketTicker:         "thb_btc",
+		api.MarketBooks:          "thb_btc",
+		api.MarketBids:           "thb_btc",
+		api.TradingviewHistory:   "BTC_THB",
+		api.MarketMyOpenOrderV3:  "btc_thb",
+		api.MarketPlaceBidV3:     "btc_thb",
+		api.MarketCancelOrderV3:  "thb_btc",
+		api.MarketPlaceAskByFiat: "THB_BTC",
+	}, sent)
+
+	assert.Equal(t, "market.ticker.thb_btc", bksdk.TickerStream("btc_thb"))
+	assert.Equal(t, "market.trade.thb_btc", bksdk.TradeStream("BTC_THB"))
+}
@@ -0,0 +1,191 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// symbolsServer serves the market symbols fixture and records the payload of the last order.
+func symbolsServer(t *testing.T, symbolsCalls *atomic.Int32) (*httptest.Server, func() map[string]any) {
+	var mu sync.Mutex
+	var payload map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api.ServertimeV3:
+			w.Write([]byte(`1702396382000`))
+		case api.MarketSymbolV3:
+			symbolsCalls.Add(1)
+			body, _ := os.ReadFile(filepath.Join("testdata", "market_symbols_v3.json"))
+			w.Write(body)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			payload = nil
+			assert.NoError(t, json.Unmarshal(body, &payload))
+			mu.Unlock()
+			w.Write([]byte(`{"error":0,"result":{"id":"1","typ":"limit","amt":1,"rat":1,"ts":1702396382}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return payload
+	}
+}
+
+// TestSymbolInfo checks the metadata of the markets and its cache.
+func TestSymbolInfo(t *testing.T) {
+	var symbolsCalls atomic.Int32
+	srv, _ := symbolsServer(t, &symbolsCalls)
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(srv.URL), bksdk.WithSymbolRefreshInterval(50*time.Millisecond))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	info, err := sdk.SymbolInfoCtx(ctx, "btc_thb")
+	assert.NoError(t, err)
+	assert.Equal(t, "BTC", info.BaseAsset)
+	assert.Equal(t, "THB", info.QuoteAsset)
+	assert.Equal(t, 1, info.PairingID)
+	assert.Equal(t, dec("0.01"), info.PriceStep)
+	assert.Equal(t, dec("0.00000001"), info.VolumeStep)
+	assert.Equal(t, dec("10"), info.MinQuoteSize)
+
+	// The metadata is cached until the refresh interval
+	info, err = sdk.SymbolInfoCtx(ctx, "DOGE_THB")
+	assert.NoError(t, err)
+	assert.Equal(t, dec("0.0001"), info.PriceStep)
+	assert.Equal(t, int32(1), symbolsCalls.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = sdk.SymbolInfoCtx(ctx, "btc_thb")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), symbolsCalls.Load())
+
+	_, err = sdk.SymbolInfoCtx(ctx, "shib_thb")
+	assert.ErrorIs(t, err, bkerr.ErrUnknownSymbol)
+
+	// The refresh interval must be positive
+	_, err = bksdk.NewWithOptions("", "", bksdk.WithSymbolRefreshInterval(0))
+	assert.Error(t, err)
+	_, err = bksdk.NewWithOptions("", "", bksdk.WithSymbolRefreshInterval(-time.Minute))
+	assert.Error(t, err)
+}
+
+// TestSymbolInfoSlow checks that a slow load does not hold the other lookups past their own context,
+// and that the lookups waiting for it share its result.
+func TestSymbolInfoSlow(t *testing.T) {
+	release := make(chan struct{})
+	var symbolsCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbolsCalls.Add(1)
+		<-release
+		body, _ := os.ReadFile(filepath.Join("testdata", "market_symbols_v3.json"))
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	// The first lookup starts the load without a deadline
+	done := make(chan error, 1)
+	go func() {
+		_, err := sdk.SymbolInfo("btc_thb")
+		done <- err
+	}()
+	for symbolsCalls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A lookup with a deadline gives up waiting for it
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err = sdk.SymbolInfoCtx(ctx, "btc_thb")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+
+	// A lookup without a deadline waits for the load in flight instead of starting another one
+	waiting := make(chan error, 1)
+	go func() {
+		_, err := sdk.SymbolInfo("doge_thb")
+		waiting <- err
+	}()
+
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, <-waiting)
+	assert.Equal(t, int32(1), symbolsCalls.Load())
+}
+
+// TestPrecision checks that the orders are rejected or rounded to the rules of their market before they are sent.
+func TestPrecision(t *testing.T) {
+	var symbolsCalls atomic.Int32
+	srv, last := symbolsServer(t, &symbolsCalls)
+	ctx := context.Background()
+
+	t.Run("reject", func(t *testing.T) {
+		sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL), bksdk.WithPrecision(bksdk.PrecisionReject))
+		assert.NoError(t, err)
+
+		tests := []struct {
+			name  string
+			order *bksdk.Order
+			err   error
+		}{
+			{"rate off the tick size", bksdk.NewOrder().Buy("btc_thb").Limit(dec("1500000.005")).Amount(dec("1000")), bkerr.ErrImproperRate},
+			{"amount with too many decimals", bksdk.NewOrder().Buy("btc_thb").Limit(dec("1500000")).Amount(dec("1000.123")), bkerr.ErrInvalidAmount},
+			{"amount off the lot size", bksdk.NewOrder().Sell("doge_thb").Limit(dec("3.5")).Amount(dec("100.555")), bkerr.ErrInvalidAmount},
+			{"value below the minimum", bksdk.NewOrder().Buy("btc_thb").Market().Amount(dec("5")), bkerr.ErrAmountTooLow},
+			{"ask value below the minimum", bksdk.NewOrder().Sell("doge_thb").Limit(dec("3")).Amount(dec("2")), bkerr.ErrAmountTooLow},
+			{"unknown symbol", bksdk.NewOrder().Buy("shib_thb").Market().Amount(dec("1000")), bkerr.ErrUnknownSymbol},
+			{"valid", bksdk.NewOrder().Sell("doge_thb").Limit(dec("3.5")).Amount(dec("100.5")), nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := sdk.Place(ctx, tt.order)
+				if tt.err == nil {
+					assert.NoError(t, err)
+					return
+				}
+				assert.ErrorIs(t, err, tt.err)
+			})
+		}
+	})
+
+	t.Run("round", func(t *testing.T) {
+		sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(srv.URL), bksdk.WithPrecision(bksdk.PrecisionRound))
+		assert.NoError(t, err)
+
+		// A bid rate is rounded down, an ask rate up, and the amounts down
+		_, err = sdk.PlaceBid("btc_thb", dec("1000.129"), dec("1500000.005"), "limit", "")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"sym": "btc_thb", "typ": "limit", "client_id": "", "amt": 1000.12, "rat": 1500000.0}, last())
+
+		_, err = sdk.PlaceAsk("doge_thb", dec("100.555"), dec("3.50001"), "limit", "")
+		assert.NoError(t, err)
+		assert.Equal(t, 100.55, last()["amt"])
+		assert.Equal(t, 3.5001, last()["rat"])
+
+		// An order below the minimum can not be rounded
+		_, err = sdk.PlaceAsk("doge_thb", dec("2"), dec("3"), "limit", "")
+		assert.ErrorIs(t, err, bkerr.ErrAmountTooLow)
+	})
+}
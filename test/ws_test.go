@@ -0,0 +1,364 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/naruebaet/bitkub-sdk/bksdk"
+	"github.com/naruebaet/bitkub-sdk/bksdk/api"
+	"github.com/naruebaet/bitkub-sdk/bksdk/bkerr"
+	"github.com/naruebaet/bitkub-sdk/bksdk/decimal"
+	"github.com/naruebaet/bitkub-sdk/bksdk/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// wsServer is a fake Bitkub websocket server.
+// It records the streams of every connection and lets the test push messages to the last one.
+type wsServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	conns    []*websocket.Conn
+	streams  []string
+	dials    chan string
+	received chan string
+}
+
+func newWsServer(t *testing.T) *wsServer {
+	s := &wsServer{dials: make(chan string, 16), received: make(chan string, 16)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		streams := strings.TrimPrefix(r.URL.Path, "/websocket-api/")
+
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.streams = append(s.streams, streams)
+		s.mu.Unlock()
+		s.dials <- streams
+
+		// Read until the client goes away, answering pings
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			s.received <- string(message)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// host returns the websocket host of the server.
+func (s *wsServer) host() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + "/websocket-api/"
+}
+
+// last returns the last connection.
+func (s *wsServer) last() *websocket.Conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns[len(s.conns)-1]
+}
+
+// send writes a message to the last connection.
+func (s *wsServer) send(t *testing.T, message string) {
+	assert.NoError(t, s.last().WriteMessage(websocket.TextMessage, []byte(message)))
+}
+
+// waitDial waits for the next connection and returns its streams.
+func (s *wsServer) waitDial(t *testing.T) string {
+	select {
+	case streams := <-s.dials:
+		return streams
+	case <-time.After(5 * time.Second):
+		t.Fatal("no connection")
+		return ""
+	}
+}
+
+// TestWsClient checks the typed channels, the resubscription and the reconnection of the websocket client.
+func TestWsClient(t *testing.T) {
+	srv := newWsServer(t)
+
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithWSHost(srv.host()))
+	assert.NoError(t, err)
+
+	client := sdk.NewWsClient(context.Background(),
+		bksdk.WithWsReconnectPolicy(bksdk.RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}))
+	defer client.Close()
+
+	// Subscribe to a ticker stream
+	assert.NoError(t, client.Subscribe("market.ticker.thb_btc"))
+	assert.Equal(t, "market.ticker.thb_btc", srv.waitDial(t))
+
+	srv.send(t, `{"stream":"market.ticker.thb_btc","id":1,"last":1504031.12}`)
+	ticker := <-client.Tickers()
+	assert.Equal(t, dec("1504031.12"), ticker.Last)
+
+	// Subscribing to another stream reconnects with both streams
+	assert.NoError(t, client.Subscribe("market.trade.thb_btc", "market.ticker.thb_btc"))
+	assert.Equal(t, "market.ticker.thb_btc,market.trade.thb_btc", srv.waitDial(t))
+
+	srv.send(t, `{"stream":"market.trade.thb_btc","txn":"BTCSELL0021206932","rat":1504031.12,"amt":0.0001}`)
+	trade := <-client.Trades()
+	assert.Equal(t, "BTCSELL0021206932", trade.Txn)
+
+	// A frame carrying several newline-delimited messages is split and routed by stream
+	srv.send(t, `{"stream":"market.ticker.thb_btc","id":1,"last":1504031.5}`+"\n"+
+		`{"stream":"market.trade.thb_btc","txn":"BTCBUY0021206933","rat":1504031.5,"amt":0.0002}`+"\n")
+	ticker = <-client.Tickers()
+	assert.Equal(t, "thb_btc", ticker.Symbol)
+	assert.Equal(t, dec("1504031.5"), ticker.Last)
+	trade = <-client.Trades()
+	assert.Equal(t, "thb_btc", trade.Symbol)
+	assert.Equal(t, "BTCBUY0021206933", trade.Txn)
+
+	// A message that can not be decoded is reported and skipped
+	srv.send(t, `not json`)
+	wsErr := &bkerr.WsError{}
+	assert.True(t, errors.As(<-client.Errors(), &wsErr))
+	assert.Equal(t, "decode", wsErr.Op)
+
+	// A dropped connection is reported and reconnected with the same streams
+	srv.last().Close()
+	assert.Equal(t, "market.ticker.thb_btc,market.trade.thb_btc", srv.waitDial(t))
+	assert.True(t, errors.As(<-client.Errors(), &wsErr))
+	assert.Equal(t, "read", wsErr.Op)
+
+	srv.send(t, `{"stream":"market.ticker.thb_btc","id":1,"last":1504032}`)
+	ticker = <-client.Tickers()
+	assert.Equal(t, dec("1504032"), ticker.Last)
+
+	// Close stops the client and closes the channels
+	assert.NoError(t, client.Close())
+	_, ok := <-client.Tickers()
+	assert.False(t, ok)
+	assert.ErrorIs(t, client.Subscribe("market.ticker.thb_eth"), bkerr.ErrWsClosed)
+}
+
+// TestWsClientGiveUp checks that the client stops after MaxAttempts failed connections.
+func TestWsClientGiveUp(t *testing.T) {
+	// Nothing listens on this port
+	sdk, err := bksdk.NewWithOptions("", "", bksdk.WithWSHost("ws://127.0.0.1:1/websocket-api/"))
+	assert.NoError(t, err)
+
+	client := sdk.NewWsClient(context.Background(),
+		bksdk.WithWsReconnectPolicy(bksdk.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	defer client.Close()
+	assert.NoError(t, client.Subscribe("market.ticker.thb_btc"))
+
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not give up")
+	}
+
+	var dialErrors int
+	for err := range client.Errors() {
+		wsErr := &bkerr.WsError{}
+		if errors.As(err, &wsErr) && wsErr.Op == "dial" {
+			dialErrors++
+		}
+	}
+	assert.Equal(t, 2, dialErrors)
+}
+
+// TestPrivateWsClient checks the authentication, the typed private events and the token renewal of the private client.
+func TestPrivateWsClient(t *testing.T) {
+	wsSrv := newWsServer(t)
+
+	// Every call to the wstoken endpoint returns a new token
+	var tokens atomic.Int32
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api.ServertimeV3:
+			w.Write([]byte(`1702396382000`))
+		case api.MarketWstokenV3:
+			fmt.Fprintf(w, `{"error":0,"result":"token-%d"}`, tokens.Add(1))
+		}
+	}))
+	defer apiSrv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(apiSrv.URL), bksdk.WithWSHost(wsSrv.host()))
+	assert.NoError(t, err)
+
+	// The private client is experimental and left out of SDKEndpoints
+	client := sdk.(*bksdk.SDK).NewPrivateWsClient(context.Background(), bksdk.WithWsTokenTTL(300*time.Millisecond))
+	defer client.Close()
+
+	// The connection is authenticated with a token from WsToken
+	assert.Equal(t, bksdk.WS_PRIVATE_STREAM, wsSrv.waitDial(t))
+	assert.JSONEq(t, `{"event":"auth","token":"token-1"}`, <-wsSrv.received)
+
+	// Order updates and matches are delivered on their typed channels, control messages are skipped
+	wsSrv.send(t, `{"event":"auth","status":"ok"}`+"\n"+
+		`{"stream":"private.order","id":"2","sym":"DOGE_THB","side":"buy","type":"limit","rate":"2.8134","amount":"100","filled":"40.5","remaining":"59.5","status":"partial","client_id":"bot-1","ts":1702543272000}`+"\n"+
+		`{"stream":"private.match","txn_id":"DOGEBUY0000124587","order_id":"2","side":"buy","rate":"2.8134","amount":"40.5","fee":"0.28","is_maker":true,"ts":1702543272000}`)
+
+	order := <-client.Orders()
+	assert.Equal(t, response.StringOrNumber("2"), order.ID)
+	assert.Equal(t, "partial", order.Status)
+	assert.Equal(t, dec("59.5"), order.Remaining)
+
+	match := <-client.Matches()
+	assert.Equal(t, "DOGEBUY0000124587", match.TxnID)
+	assert.Equal(t, dec("0.28"), match.Fee)
+	assert.True(t, match.IsMaker)
+
+	// The connection is renewed with a new token before the token expires
+	assert.Equal(t, bksdk.WS_PRIVATE_STREAM, wsSrv.waitDial(t))
+	assert.JSONEq(t, `{"event":"auth","token":"token-2"}`, <-wsSrv.received)
+	wsSrv.send(t, `{"event":"auth","status":"ok"}`)
+
+	select {
+	case err := <-client.Errors():
+		t.Fatalf("unexpected error: %v", err)
+	default:
+	}
+}
+
+// TestPrivateWsClientAuthRejected checks that a rejected token is reported and followed by a reconnection.
+func TestPrivateWsClientAuthRejected(t *testing.T) {
+	wsSrv := newWsServer(t)
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api.ServertimeV3:
+			w.Write([]byte(`1702396382000`))
+		case api.MarketWstokenV3:
+			w.Write([]byte(`{"error":0,"result":"expired-token"}`))
+		}
+	}))
+	defer apiSrv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(apiSrv.URL), bksdk.WithWSHost(wsSrv.host()))
+	assert.NoError(t, err)
+
+	client := sdk.(*bksdk.SDK).NewPrivateWsClient(context.Background(),
+		bksdk.WithWsReconnectPolicy(bksdk.RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}))
+	defer client.Close()
+
+	assert.Equal(t, bksdk.WS_PRIVATE_STREAM, wsSrv.waitDial(t))
+	<-wsSrv.received
+	wsSrv.send(t, `{"event":"auth","status":"error","message":"invalid token"}`)
+
+	wsErr := &bkerr.WsError{}
+	assert.True(t, errors.As(<-client.Errors(), &wsErr))
+	assert.Equal(t, "auth", wsErr.Op)
+	assert.ErrorIs(t, wsErr, bkerr.ErrWsAuth)
+	assert.Contains(t, wsErr.Error(), "invalid token")
+
+	// The client reconnects with a new token
+	assert.Equal(t, bksdk.WS_PRIVATE_STREAM, wsSrv.waitDial(t))
+}
+
+// TestPrivateWsClientCloseDuringAuth checks that closing the client does not wait for the answer to the authentication.
+func TestPrivateWsClientCloseDuringAuth(t *testing.T) {
+	wsSrv := newWsServer(t)
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case api.ServertimeV3:
+			w.Write([]byte(`1702396382000`))
+		case api.MarketWstokenV3:
+			w.Write([]byte(`{"error":0,"result":"token"}`))
+		}
+	}))
+	defer apiSrv.Close()
+
+	sdk, err := bksdk.NewWithOptions("key", "secret", bksdk.WithBaseURL(apiSrv.URL), bksdk.WithWSHost(wsSrv.host()))
+	assert.NoError(t, err)
+
+	client := sdk.(*bksdk.SDK).NewPrivateWsClient(context.Background())
+	wsSrv.waitDial(t)
+	<-wsSrv.received
+
+	// The server never answers the authentication
+	started := time.Now()
+	client.Close()
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+// TestWsClientBufferPolicy checks what each buffer policy does with the messages of a slow consumer.
+func TestWsClientBufferPolicy(t *testing.T) {
+	frame := `{"stream":"market.ticker.thb_btc","last":1}` + "\n" +
+		`{"stream":"market.ticker.thb_btc","last":2}` + "\n" +
+		`{"stream":"market.ticker.thb_btc","last":3}` + "\n" +
+		`{"stream":"market.ticker.thb_eth","last":10}`
+
+	tests := []struct {
+		name    string
+		policy  bksdk.WsBufferPolicy
+		dropped uint64
+		want    []decimal.Decimal
+	}{
+		{"drop newest", bksdk.WsDropNewest, 3, []decimal.Decimal{dec("1")}},
+		{"drop oldest", bksdk.WsDropOldest, 3, []decimal.Decimal{dec("10")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newWsServer(t)
+			sdk, err := bksdk.NewWithOptions("", "", bksdk.WithWSHost(srv.host()))
+			assert.NoError(t, err)
+			client := sdk.NewWsClient(context.Background(), bksdk.WithWsBufferSize(1), bksdk.WithWsBufferPolicy(tt.policy))
+			defer client.Close()
+			assert.NoError(t, client.Subscribe("market.ticker.thb_btc", "market.ticker.thb_eth"))
+			srv.waitDial(t)
+
+			srv.send(t, frame)
+			assert.Eventually(t, func() bool {
+				var total uint64
+				for _, n := range client.Dropped() {
+					total += n
+				}
+				return total == tt.dropped
+			}, 5*time.Second, 10*time.Millisecond)
+
+			var got []decimal.Decimal
+			for len(got) < len(tt.want) {
+				got = append(got, (<-client.Tickers()).Last)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Conflation keeps the latest ticker of each symbol
+	t.Run("conflate", func(t *testing.T) {
+		srv := newWsServer(t)
+		sdk, err := bksdk.NewWithOptions("", "", bksdk.WithWSHost(srv.host()))
+		assert.NoError(t, err)
+		client := sdk.NewWsClient(context.Background(), bksdk.WithWsBufferPolicy(bksdk.WsConflate))
+		defer client.Close()
+		assert.NoError(t, client.Subscribe("market.ticker.thb_btc", "market.ticker.thb_eth"))
+		srv.waitDial(t)
+
+		srv.send(t, frame)
+		assert.Eventually(t, func() bool {
+			return client.Dropped()["market.ticker.thb_btc"] >= 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// The first ticker may already be on its way to the consumer, the others are replaced by the latest
+		var btc []decimal.Decimal
+		for {
+			ticker := <-client.Tickers()
+			if ticker.Symbol == "thb_eth" {
+				assert.Equal(t, dec("10"), ticker.Last)
+				break
+			}
+			btc = append(btc, ticker.Last)
+		}
+		assert.Equal(t, dec("3"), btc[len(btc)-1])
+		assert.Equal(t, uint64(3), uint64(len(btc))+client.Dropped()["market.ticker.thb_btc"])
+	})
+}